@@ -0,0 +1,85 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// Character codes prefixing the value of UserComment, GPSProcessingMethod and GPSAreaInformation.
+// See table 9 of Exif 2.31.
+var (
+	charCodeASCII     = []byte("ASCII\x00\x00\x00")
+	charCodeJIS       = []byte("JIS\x00\x00\x00\x00\x00")
+	charCodeUnicode   = []byte("UNICODE\x00")
+	charCodeUndefined = []byte("\x00\x00\x00\x00\x00\x00\x00\x00")
+)
+
+// formatComment decodes a tag recorded as UNDEFINED, whose 8 first bytes identify the character code of the text:
+//   - ASCII for ITU-T T.50 IA5 text
+//   - JIS for JIS X0208-1990 text
+//   - UNICODE for UCS-2 text, using the byte order of the TIFF header
+//   - undefined, handled as ASCII
+//
+// Padding NULs and spaces are removed. A value without character code is returned as is.
+func formatComment(t Tag) string {
+	if len(t.Data) < 8 {
+		return trimComment(string(t.Data))
+	}
+	code, text := t.Data[:8], t.Data[8:]
+
+	switch {
+	case bytes.Equal(code, charCodeASCII), bytes.Equal(code, charCodeUndefined):
+		return trimComment(string(text))
+	case bytes.Equal(code, charCodeJIS):
+		return trimComment(jisToString(text))
+	case bytes.Equal(code, charCodeUnicode):
+		return trimComment(ucs2ToString(text, t.bo))
+	}
+	return trimComment(string(t.Data))
+}
+
+func trimComment(s string) string {
+	return strings.TrimRight(s, "\x00 ")
+}
+
+// jisToString decodes JIS X0208 text, recorded either as raw 2-byte codes or as ISO-2022-JP with escape sequences.
+// Undecodable text is returned as is.
+func jisToString(data []byte) string {
+	// NULs and spaces used as padding are never part of a 2-byte code
+	text := bytes.TrimRight(data, "\x00 ")
+	if bytes.IndexByte(text, 0x1b) < 0 { // raw codes, switch to JIS X0208 as ISO-2022-JP would do
+		text = append([]byte("\x1b$B"), text...)
+	}
+	s, err := japanese.ISO2022JP.NewDecoder().Bytes(text)
+	if err != nil {
+		return string(data)
+	}
+	return string(s)
+}
+
+// ucs2ToString decodes UCS-2 text using byte order bo, unless the text starts with a byte order mark.
+func ucs2ToString(data []byte, bo binary.ByteOrder) string {
+	if bo == nil {
+		bo = binary.BigEndian
+	}
+	if len(data) >= 2 {
+		switch {
+		case data[0] == 0xfe && data[1] == 0xff:
+			bo, data = binary.BigEndian, data[2:]
+		case data[0] == 0xff && data[1] == 0xfe:
+			bo, data = binary.LittleEndian, data[2:]
+		}
+	}
+	u := make([]uint16, len(data)/2)
+	for i := range u {
+		u[i] = bo.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(u))
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dates are recorded as "YYYY:MM:DD HH:MM:SS" in local time, offsets as "+HH:MM" or "-HH:MM".
+const (
+	dateTimeLayout = "2006:01:02 15:04:05"
+	dateLayout     = "2006:01:02"
+)
+
+// OriginalTime returns the date and time when the original image data was generated,
+// combining DateTimeOriginal, SubSecTimeOriginal and OffsetTimeOriginal.
+//
+// Without OffsetTimeOriginal, the time is interpreted in loc, or in UTC if loc is nil.
+// ok is false if the date is missing or malformed.
+func (x *Exif) OriginalTime(loc *time.Location) (t time.Time, ok bool) {
+	return parseDateTime(x.Photo.DateTimeOriginal, x.Photo.SubSecTimeOriginal, x.Photo.OffsetTimeOriginal, loc)
+}
+
+// DigitizedTime returns the date and time when the image was stored as digital data,
+// combining DateTimeDigitized, SubSecTimeDigitized and OffsetTimeDigitized.
+//
+// Without OffsetTimeDigitized, the time is interpreted in loc, or in UTC if loc is nil.
+// ok is false if the date is missing or malformed.
+func (x *Exif) DigitizedTime(loc *time.Location) (t time.Time, ok bool) {
+	return parseDateTime(x.Photo.DateTimeDigitized, x.Photo.SubSecTimeDigitized, x.Photo.OffsetTimeDigitized, loc)
+}
+
+// ModifiedTime returns the date and time when the file was changed,
+// combining DateTime from IFD0 with SubSecTime and OffsetTime from the Exif IFD.
+//
+// Without OffsetTime, the time is interpreted in loc, or in UTC if loc is nil.
+// ok is false if the date is missing or malformed.
+func (x *Exif) ModifiedTime(loc *time.Location) (t time.Time, ok bool) {
+	return parseDateTime(x.Image.DateTime, x.Photo.SubSecTime, x.Photo.OffsetTime, loc)
+}
+
+// GPSTime returns the date and time recorded by the GPS receiver, combining GPSDateStamp and GPSTimeStamp.
+// The time is in UTC, with the fractions of seconds recorded by GPSTimeStamp if any.
+// ok is false if one of the tags is missing or malformed.
+func (x *Exif) GPSTime() (t time.Time, ok bool) {
+	date, err := time.ParseInLocation(dateLayout, strings.TrimSpace(x.Gps.GPSDateStamp), time.UTC)
+	if err != nil {
+		return time.Time{}, false
+	}
+	tag, ok := x.Tag("GPS", "GPSTimeStamp")
+	if !ok || tag.Type != TypeRational || tag.Count != 3 {
+		return time.Time{}, false
+	}
+	var d time.Duration
+	hms := tag.rationalToRational()
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		r := hms[i]
+		if r.Den == 0 {
+			return time.Time{}, false
+		}
+		d += time.Duration(r.Float64() * float64(unit))
+	}
+	return date.Add(d.Round(time.Millisecond)), true
+}
+
+// parseDateTime combines a date, its fractions of seconds and its offset from UTC.
+// Exif allows unknown parts to be replaced by spaces, such parts are handled as missing.
+func parseDateTime(dateTime, subSec, offset string, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if zone, ok := parseOffset(offset); ok {
+		loc = zone
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, strings.TrimSpace(dateTime), loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.Add(parseSubSec(subSec)), true
+}
+
+// parseOffset returns a fixed zone for an offset formatted as "+HH:MM" or "-HH:MM".
+func parseOffset(s string) (*time.Location, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) != 6 || (s[0] != '+' && s[0] != '-') || s[3] != ':' {
+		return nil, false
+	}
+	h, err1 := strconv.Atoi(s[1:3])
+	m, err2 := strconv.Atoi(s[4:6])
+	if err1 != nil || err2 != nil || h > 14 || m > 59 {
+		return nil, false
+	}
+	secs := (h*60 + m) * 60
+	if s[0] == '-' {
+		secs = -secs
+	}
+	return time.FixedZone(s, secs), true
+}
+
+// parseSubSec returns the duration recorded as the decimal digits of a fraction of second, like "72" for 0.72s.
+func parseSubSec(s string) time.Duration {
+	s = strings.TrimSpace(s)
+	if len(s) > 9 {
+		s = s[:9] // nanosecond precision
+	}
+	if s == "" {
+		return 0
+	}
+	ns, err := strconv.Atoi(s + strings.Repeat("0", 9-len(s)))
+	if err != nil || ns < 0 {
+		return 0
+	}
+	return time.Duration(ns)
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned by nifuda, to be tested using errors.Is.
+var (
+	// ErrNotExif is returned when data is neither a JPEG nor a TIFF file.
+	ErrNotExif = errors.New("not an exif file")
+	// ErrNoExifData is returned when a JPEG file has no APP1 Exif segment.
+	ErrNoExifData = errors.New("no Exif data found")
+	// ErrUnsupportedFormat is returned when a file uses a variant of the format which is not supported.
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	// ErrNoThumbnail is returned when no thumbnail is recorded in IFD1.
+	ErrNoThumbnail = errors.New("no thumbnail found")
+)
+
+// FormatError reports malformed data, locating where the problem occurred.
+// The underlying error is available using errors.Unwrap, errors.Is or errors.As.
+type FormatError struct {
+	Container string // "JPEG" or "TIFF"
+	IFD       string // name of the IFD, as used as key in Exif.IFDs. Empty if not relevant
+	Tag       uint16 // tag identifier, 0 if not relevant
+	Offset    uint64 // offset in bytes, from the start of the JPEG file or from the start of the TIFF header
+	Err       error  // underlying error
+}
+
+func (e *FormatError) Error() string {
+	var where []string
+	if e.IFD != "" {
+		where = append(where, e.IFD)
+	}
+	if e.Tag != 0 {
+		where = append(where, fmt.Sprintf("tag %d", e.Tag))
+	}
+	where = append(where, fmt.Sprintf("offset %d", e.Offset))
+	return fmt.Sprintf("%s: %s: %s", strings.ToLower(e.Container), strings.Join(where, ", "), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+func jpegError(offset uint64, err error) *FormatError {
+	return &FormatError{Container: "JPEG", Offset: offset, Err: err}
+}
+
+func tiffError(ifd string, tag uint16, offset uint64, err error) *FormatError {
+	return &FormatError{Container: "TIFF", IFD: ifd, Tag: tag, Offset: offset, Err: err}
+}
@@ -3,34 +3,154 @@
 
 package nifuda
 
-import (
-	"errors"
-	"io"
-)
+import "io"
 
 // Exif provides access to decoded EXIF tags.
+//
+// Raw entries of each IFD read are also available in IFDs, keyed by IFD name:
+//   - "IFD0", "IFD1", ... for the chain of IFDs starting from IFD0, each one pointed by the Next offset of its predecessor
+//   - "Exif", "GPS" and "Interop" for the Exif, GPS and Interoperability sub-IFDs
+//   - "IFD0.SubIFD0", "IFD0.SubIFD1", ... for the IFDs pointed by a SubIFDs tag, named after their parent
+//   - "Nikon" and "Canon" for the IFD of a Nikon or Canon MakerNote
+//
+// The IFDs of the chain are also listed in Chain, in the order they are linked.
 type Exif struct {
-	Image ImageTags
-	Photo PhotoTags
-	Gps   GpsTags
+	Image          ImageTags
+	ThumbnailImage ImageTags // tags from IFD1, describing the thumbnail
+	Photo          PhotoTags
+	Gps            GpsTags
+	Interop        InteropTags
+	SubIFDs        []SubIFDTags // in the order they were read, a SubIFD being followed by its own SubIFDs
+	Nikon          *NikonTags   // decoded from the MakerNote of Nikon cameras, nil for other cameras
+	Canon          *CanonTags   // decoded from the MakerNote of Canon cameras, nil for other cameras
+	IFDs           map[string]*IFD
+	Chain          []*IFD // IFD0, IFD1, ... each one pointed by the Next offset of its predecessor
+
+	// Warnings lists the problems encountered while decoding in lenient mode,
+	// and in any mode the problems with optional data like the thumbnail, which is then ignored.
+	Warnings []Warning
+
+	thumbnail []byte // JPEG thumbnail from IFD1
+}
+
+// Tag returns the raw tag with the given name from the IFD with the given name, as used as key in IFDs.
+// The tag must have a definition, either built in or added using RegisterTag.
+func (x *Exif) Tag(ifd string, name string) (Tag, bool) {
+	d, ok := x.IFDs[ifd]
+	if !ok {
+		return Tag{}, false
+	}
+	def, ok := LookupTagByName(ifd, name)
+	if !ok {
+		return Tag{}, false
+	}
+	return d.Tag(def.ID)
+}
+
+// Has reports whether the tag with the given name is recorded in the IFD with the given name.
+// Fields of a tag which is not recorded are left to their zero value, which for some enumerations like
+// ExposureMode is also a valid value: Has tells them apart.
+func (x *Exif) Has(ifd string, name string) bool {
+	_, ok := x.Tag(ifd, name)
+	return ok
+}
+
+// Location returns the position recorded by the GPS tags, as signed decimal degrees and meters above sea level.
+// ok is false if the latitude or the longitude is missing. alt is 0 if the altitude is missing.
+func (x *Exif) Location() (lat, lon, alt float64, ok bool) {
+	g := x.Gps
+	if g.GPSLatitude.Degrees.Den == 0 || g.GPSLongitude.Degrees.Den == 0 {
+		return 0, 0, 0, false
+	}
+	alt = g.GPSAltitude.Float64()
+	if g.GPSAltitudeRef == AltitudeBelowSeaLevel {
+		alt = -alt
+	}
+	return g.Latitude(), g.Longitude(), alt, true
+}
+
+// Options controls the decoding of EXIF data.
+type Options struct {
+	// Strict aborts the decoding at the first problem encountered.
+	// Otherwise, decoding is lenient: IFDs and tags which can not be read are skipped, reported
+	// in Exif.Warnings, and all what could be decoded is returned.
+	Strict bool
+}
+
+// Warning describes a problem encountered while decoding in lenient mode.
+type Warning struct {
+	IFD    string // name of the IFD, as used as key in Exif.IFDs
+	Tag    uint16 // tag identifier, 0 if the problem concerns the IFD itself
+	Offset uint64 // offset in bytes of the data which can not be read, from the start of the TIFF header
+	Reason string // description of the problem
+}
+
+func (w Warning) String() string {
+	return w.Reason
 }
 
-// Read decode EXIF data from an io.ReadSeeker.
+// Read decode EXIF data from an io.ReadSeeker, aborting at the first problem encountered.
 func Read(rs io.ReadSeeker) (*Exif, error) {
+	return ReadWithOptions(rs, Options{Strict: true})
+}
+
+// ReadWithOptions decode EXIF data from an io.ReadSeeker, using opts to control the decoding.
+func ReadWithOptions(rs io.ReadSeeker, opts Options) (*Exif, error) {
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
 
-	var a [2]byte
-	b := a[:]
-	rs.Read(b)
-	rs.Seek(0, io.SeekStart)
+	// *os.File, *bytes.Reader, ... already implement io.ReaderAt
+	if r, ok := rs.(io.ReaderAt); ok {
+		return ReadAtWithOptions(r, size, opts)
+	}
+	return ReadAtWithOptions(readSeekerAt{rs}, size, opts)
+}
+
+// ReadAt decode EXIF data of size bytes from an io.ReaderAt, aborting at the first problem encountered.
+//
+// Data is only accessed using ReadAt, without relying on a shared cursor: the same io.ReaderAt,
+// for example an *os.File or a memory-mapped buffer, can be decoded from several goroutines.
+func ReadAt(r io.ReaderAt, size int64) (*Exif, error) {
+	return ReadAtWithOptions(r, size, Options{Strict: true})
+}
+
+// ReadAtWithOptions decode EXIF data of size bytes from an io.ReaderAt, using opts to control the decoding.
+func ReadAtWithOptions(r io.ReaderAt, size int64, opts Options) (*Exif, error) {
+	b := make([]byte, 2)
+	if n, _ := r.ReadAt(b, 0); n < len(b) {
+		return nil, ErrNotExif
+	}
 
 	switch string(b) {
 	case "\xff\xd8": // SOI
-		x, err := jpegRead(rs)
+		x, err := jpegRead(r, size, opts)
 		return x, err
 	case "II", "MM":
-		x, err := tiffRead(rs)
+		x, err := tiffRead(r, size, opts)
 		return x, err
 	default:
-		return nil, errors.New("not an exif file")
+		return nil, ErrNotExif
+	}
+}
+
+// readSeekerAt adapts an io.ReadSeeker to io.ReaderAt, by seeking before each read.
+type readSeekerAt struct {
+	rs io.ReadSeeker
+}
+
+func (r readSeekerAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := r.rs.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.ReadFull(r.rs, p)
+}
+
+// Thumbnail returns the JPEG compressed thumbnail image recorded in IFD1.
+func (x *Exif) Thumbnail() ([]byte, error) {
+	if len(x.thumbnail) == 0 {
+		return nil, ErrNoThumbnail
 	}
+	return x.thumbnail, nil
 }
@@ -0,0 +1,46 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzRead checks that decoding arbitrary data never panics, in strict and lenient modes.
+// Corpus is seeded from the files of testdata.
+//
+// Run with: go test -fuzz=FuzzRead
+func FuzzRead(f *testing.F) {
+	err := filepath.Walk("./testdata", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) == ".json" || filepath.Ext(path) == ".sh" {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		f.Add(data)
+		return nil
+	})
+	if err != nil {
+		f.Fatalf("seeding corpus failed, error=%s", err)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, opts := range []Options{{Strict: true}, {Strict: false}} {
+			x, err := ReadWithOptions(bytes.NewReader(data), opts)
+			if err != nil {
+				if x != nil {
+					t.Errorf("Exif should be nil on error, strict=%t, error=%s", opts.Strict, err)
+				}
+				continue
+			}
+			x.Thumbnail()
+		}
+	})
+}
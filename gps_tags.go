@@ -4,175 +4,330 @@
 package nifuda
 
 import (
-	"encoding/binary"
 	"fmt"
+	"strconv"
 )
 
 // GpsTags contains tags from GPS SubIFD.
 // Fields are defined in order they appeared in chapter 4.6.6 of Exif 2.31
 type GpsTags struct {
 	GPSVersionID         string
-	GPSLatitudeRef       string
-	GPSLatitude          string
-	GPSLongitudeRef      string
-	GPSLongitude         string
-	GPSAltitudeRef       string
-	GPSAltitude          float32
+	GPSLatitudeRef       LatitudeRef
+	GPSLatitude          Coordinate
+	GPSLongitudeRef      LongitudeRef
+	GPSLongitude         Coordinate
+	GPSAltitudeRef       AltitudeRef
+	GPSAltitude          Rational
 	GPSTimeStamp         string
 	GPSSatellites        string
-	GPSStatus            string
-	GPSDOP               float32
-	GPSMeasureMode       string
-	GPSSpeedRef          string
-	GPSSpeed             float32
-	GPSTrackRef          string
-	GPSTrack             float32
-	GPSImgDirectionRef   string
-	GPSImgDirection      float32
+	GPSStatus            GPSStatus
+	GPSDOP               Rational
+	GPSMeasureMode       GPSMeasureMode
+	GPSSpeedRef          SpeedRef
+	GPSSpeed             Rational
+	GPSTrackRef          DirectionRef
+	GPSTrack             Rational
+	GPSImgDirectionRef   DirectionRef
+	GPSImgDirection      Rational
 	GPSMapDatum          string
-	GPSDestLatitudeRef   string
-	GPSDestLatitude      string
-	GPSDestLongitudeRef  string
-	GPSDestLongitude     string
-	GPSDestBearingRef    string
-	GPSDestBearing       float32
-	GPSDestDistanceRef   string
-	GPSDestDistance      float32
+	GPSDestLatitudeRef   LatitudeRef
+	GPSDestLatitude      Coordinate
+	GPSDestLongitudeRef  LongitudeRef
+	GPSDestLongitude     Coordinate
+	GPSDestBearingRef    DirectionRef
+	GPSDestBearing       Rational
+	GPSDestDistanceRef   DistanceRef
+	GPSDestDistance      Rational
+	GPSProcessingMethod  string
+	GPSAreaInformation   string
 	GPSDateStamp         string
 	GPSDifferential      uint16
-	GPSHPositioningError float32
-}
-
-func parseIFDTagsAsGpsTags(ifd *ifd, bo binary.ByteOrder) GpsTags {
-	var t GpsTags
-
-	for _, ifdtag := range ifd.tags {
-		switch ifdtag.id {
-		case 0: // GPSVersionID
-			t.GPSVersionID = intArrayToString(ifdtag.byteToInt(bo), ".")
-		case 1: // GPSLatitudeRef
-			switch ifdtag.asciiToString() {
-			case "N":
-				t.GPSLatitudeRef = "North"
-			case "S":
-				t.GPSLatitudeRef = "South"
-			}
-		// case 2: // GPSLatitude
-		// 	r := ifdtag.rationalToFloat32(bo)
-		// 	gps.GPSLatitude = fmt.Sprintf("%2.0f %f' %f\"", r[0], r[1], r[2])
-		case 3: // GPSLongitudeRef
-			switch ifdtag.asciiToString() {
-			case "E":
-				t.GPSLongitudeRef = "East"
-			case "W":
-				t.GPSLongitudeRef = "West"
-			}
-		// case 4: // GPSLongitude
-		// 	r := ifdtag.rationalToFloat32(bo)
-		// 	gps.GPSLongitude = fmt.Sprintf("%2.0f %f' %f\"", r[0], r[1], r[2])
-		case 5: // GPSAltitudeRef
-			switch ifdtag.byteToInt(bo)[0] {
-			case 0:
-				t.GPSAltitudeRef = "Sea level"
-			case 1:
-				t.GPSAltitudeRef = "Sea level reference (negative value)"
-			}
-		case 6: // GPSAltitude
-			t.GPSAltitude = ifdtag.rationalToFloat32(bo)[0]
-		case 7: // GPSTimeStamp
-			r := ifdtag.rationalToFloat32(bo)
-			t.GPSTimeStamp = fmt.Sprintf("%02.0f:%02.0f:%02.0fZ", r[0], r[1], r[2])
-		case 8: // GPSSatellites
-			t.GPSSatellites = ifdtag.asciiToString()
-		case 9: // GPSStatus
-			switch ifdtag.asciiToString() {
-			case "A":
-				t.GPSStatus = "Measurement in progress"
-			case "V":
-				t.GPSStatus = "Measurement interrupted"
-			}
-		case 10: // GPSMeasureMode
-			switch ifdtag.asciiToString() {
-			case "2":
-				t.GPSMeasureMode = "2-dimensional measurement"
-			case "3":
-				t.GPSMeasureMode = "3-dimensional measurement"
-			}
-		case 11: // GPSDOP
-			t.GPSDOP = ifdtag.rationalToFloat32(bo)[0]
-		case 12: // GPSSpeedRef
-			t.GPSSpeedRef = ifdtag.asciiToString()
-		case 13: // GPSSpeed
-			t.GPSSpeed = ifdtag.rationalToFloat32(bo)[0]
-		case 14: // GPSTrackRef
-			switch ifdtag.asciiToString() {
-			case "M":
-				t.GPSTrackRef = "Magnetic direction"
-			case "T":
-				t.GPSTrackRef = "True direction"
-			}
-		case 15: // GPSTrack
-			t.GPSTrack = ifdtag.rationalToFloat32(bo)[0]
-		case 16: // GPSImgDirectionRef
-			switch ifdtag.asciiToString() {
-			case "M":
-				t.GPSImgDirectionRef = "Magnetic direction"
-			case "T":
-				t.GPSImgDirectionRef = "True direction"
-			}
-		case 17: // GPSImgDirection
-			t.GPSImgDirection = ifdtag.rationalToFloat32(bo)[0]
-		case 18: // GPSMapDatum
-			t.GPSMapDatum = ifdtag.asciiToString()
-		case 19: // GPSDestLatitudeRef
-			switch ifdtag.asciiToString() {
-			case "N":
-				t.GPSDestLatitudeRef = "North"
-			case "S":
-				t.GPSDestLatitudeRef = "South"
-			}
-		case 20: // GPSDestLatitude
-			r := ifdtag.rationalToFloat32(bo)
-			t.GPSDestLatitude = fmt.Sprintf("%2.0f %f' %f\"", r[0], r[1], r[2])
-		case 21: // GPSDestLongitudeRef
-			switch ifdtag.asciiToString() {
-			case "E":
-				t.GPSDestLongitudeRef = "East"
-			case "W":
-				t.GPSDestLongitudeRef = "West"
-			}
-		case 22: // GPSDestLongitude
-			r := ifdtag.rationalToFloat32(bo)
-			t.GPSDestLongitude = fmt.Sprintf("%2.0f %f' %f\"", r[0], r[1], r[2])
-		case 23: // GPSDestBearingRef
-			switch ifdtag.asciiToString() {
-			case "M":
-				t.GPSDestBearingRef = "Magnetic direction"
-			case "T":
-				t.GPSDestBearingRef = "True direction"
-			}
-		case 24: // GPSDestBearing
-			t.GPSDestBearing = ifdtag.rationalToFloat32(bo)[0]
-		case 25: // GPSDestDistanceRef
-			switch ifdtag.asciiToString() {
-			case "K":
-				t.GPSDestDistanceRef = "Kilometers"
-			case "M":
-				t.GPSDestDistanceRef = "Miles"
-			case "N":
-				t.GPSDestDistanceRef = "Nautical miles"
-			}
-		case 26: // GPSDestDistance
-			t.GPSDestDistance = ifdtag.rationalToFloat32(bo)[0]
-		case 27: // GPSProcessingMethod
-		case 28: // GPSAreaInformation
-		case 29: // GPSDateStamp
-			t.GPSDateStamp = ifdtag.asciiToString()
-		case 30: // GPSDifferential
-			t.GPSDifferential = ifdtag.shortToUint16(bo)[0]
-		case 31: // GPSHPositioningError
-			t.GPSHPositioningError = ifdtag.rationalToFloat32(bo)[0]
+	GPSHPositioningError Rational
+}
+
+// Coordinate is a latitude or a longitude, recorded as degrees, minutes and seconds.
+// Whether it is north or south, east or west, is recorded by the matching reference tag, like GPSLatitudeRef.
+type Coordinate struct {
+	Degrees Rational
+	Minutes Rational
+	Seconds Rational
+}
+
+// newCoordinate returns the coordinate recorded by the values of a GPSLatitude like tag.
+func newCoordinate(r []Rational) Coordinate {
+	var c Coordinate
+	for i, v := range []*Rational{&c.Degrees, &c.Minutes, &c.Seconds} {
+		if i < len(r) {
+			*v = r[i]
 		}
 	}
-	return t
+	return c
+}
+
+// DecimalDegrees returns the coordinate in degrees, negative for south latitudes and west longitudes
+// as given by ref, like GPSLatitudeRef. The coordinate is positive if ref is nil.
+func (c Coordinate) DecimalDegrees(ref CoordinateRef) float64 {
+	d := c.Degrees.Float64() + c.Minutes.Float64()/60 + c.Seconds.Float64()/3600
+	if ref != nil && ref.Negative() {
+		return -d
+	}
+	return d
+}
+
+// CoordinateRef is the reference of a coordinate: LatitudeRef or LongitudeRef.
+type CoordinateRef interface {
+	Negative() bool // true for south latitudes and west longitudes
+}
+
+// String returns the coordinate formatted like 35° 1' 1.032".
+func (c Coordinate) String() string {
+	return fmt.Sprintf("%s° %s' %s\"", formatFloat(c.Degrees), formatFloat(c.Minutes), formatFloat(c.Seconds))
+}
+
+func formatFloat(r Rational) string {
+	return strconv.FormatFloat(r.Float64(), 'f', -1, 64)
+}
+
+// Latitude returns GPSLatitude in decimal degrees, negative for south latitudes.
+func (t GpsTags) Latitude() float64 {
+	return t.GPSLatitude.DecimalDegrees(t.GPSLatitudeRef)
+}
+
+// Longitude returns GPSLongitude in decimal degrees, negative for west longitudes.
+func (t GpsTags) Longitude() float64 {
+	return t.GPSLongitude.DecimalDegrees(t.GPSLongitudeRef)
+}
+
+// DestLatitude returns GPSDestLatitude in decimal degrees, negative for south latitudes.
+func (t GpsTags) DestLatitude() float64 {
+	return t.GPSDestLatitude.DecimalDegrees(t.GPSDestLatitudeRef)
+}
+
+// DestLongitude returns GPSDestLongitude in decimal degrees, negative for west longitudes.
+func (t GpsTags) DestLongitude() float64 {
+	return t.GPSDestLongitude.DecimalDegrees(t.GPSDestLongitudeRef)
+}
+
+// LatitudeRef indicates whether a latitude is north or south.
+type LatitudeRef string
+
+// Latitude references defined by Exif 2.31
+const (
+	LatitudeNorth LatitudeRef = "N"
+	LatitudeSouth LatitudeRef = "S"
+)
+
+func (v LatitudeRef) String() string {
+	return describeValue("GPS", 1, "LatitudeRef", string(v))
+}
+
+// Negative reports whether latitudes with this reference are negative in decimal degrees.
+func (v LatitudeRef) Negative() bool {
+	return v == LatitudeSouth
+}
+
+// LongitudeRef indicates whether a longitude is east or west.
+type LongitudeRef string
+
+// Longitude references defined by Exif 2.31
+const (
+	LongitudeEast LongitudeRef = "E"
+	LongitudeWest LongitudeRef = "W"
+)
+
+func (v LongitudeRef) String() string {
+	return describeValue("GPS", 3, "LongitudeRef", string(v))
+}
+
+// Negative reports whether longitudes with this reference are negative in decimal degrees.
+func (v LongitudeRef) Negative() bool {
+	return v == LongitudeWest
+}
+
+// AltitudeRef indicates whether an altitude is above or below sea level.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type AltitudeRef uint8
+
+// Altitude references defined by Exif 2.31
+const (
+	AltitudeAboveSeaLevel AltitudeRef = 0
+	AltitudeBelowSeaLevel AltitudeRef = 1
+)
+
+func (v AltitudeRef) String() string {
+	return describeValue("GPS", 5, "AltitudeRef", uint8(v))
+}
+
+// GPSStatus is the status of the GPS receiver when the image was recorded.
+type GPSStatus string
+
+// GPS receiver status defined by Exif 2.31
+const (
+	GPSMeasurementInProgress  GPSStatus = "A"
+	GPSMeasurementInterrupted GPSStatus = "V"
+)
+
+func (v GPSStatus) String() string {
+	return describeValue("GPS", 9, "GPSStatus", string(v))
+}
+
+// GPSMeasureMode is the GPS measurement mode.
+type GPSMeasureMode string
+
+// GPS measurement modes defined by Exif 2.31
+const (
+	GPSMeasurement2D GPSMeasureMode = "2"
+	GPSMeasurement3D GPSMeasureMode = "3"
+)
+
+func (v GPSMeasureMode) String() string {
+	return describeValue("GPS", 10, "GPSMeasureMode", string(v))
+}
+
+// SpeedRef is the unit used to express the speed of the GPS receiver.
+type SpeedRef string
+
+// Speed units defined by Exif 2.31
+const (
+	SpeedKilometersPerHour SpeedRef = "K"
+	SpeedMilesPerHour      SpeedRef = "M"
+	SpeedKnots             SpeedRef = "N"
+)
+
+func (v SpeedRef) String() string {
+	return describeValue("GPS", 12, "SpeedRef", string(v))
+}
+
+// DirectionRef indicates whether a direction is relative to the true or the magnetic north.
+type DirectionRef string
+
+// Direction references defined by Exif 2.31
+const (
+	DirectionMagnetic DirectionRef = "M"
+	DirectionTrue     DirectionRef = "T"
+)
+
+func (v DirectionRef) String() string {
+	return describeValue("GPS", 14, "DirectionRef", string(v))
+}
+
+// DistanceRef is the unit used to express the distance to the destination point.
+type DistanceRef string
+
+// Distance units defined by Exif 2.31
+const (
+	DistanceKilometers    DistanceRef = "K"
+	DistanceMiles         DistanceRef = "M"
+	DistanceNauticalMiles DistanceRef = "N"
+)
+
+func (v DistanceRef) String() string {
+	return describeValue("GPS", 25, "DistanceRef", string(v))
+}
+
+var (
+	latitudeRefs  = map[string]string{"N": "North", "S": "South"}
+	longitudeRefs = map[string]string{"E": "East", "W": "West"}
+	directionRefs = map[string]string{"M": "Magnetic direction", "T": "True direction"}
+)
+
+var gpsTagDefs = []TagDef{
+	{ID: 0, IFD: "GPS", Name: "GPSVersionID", Types: []uint16{TypeByte}, Count: 4,
+		format:      func(t Tag) string { return intArrayToString(t.byteToInt(), ".") },
+		Description: "Version of the GPS IFD"},
+	{ID: 1, IFD: "GPS", Name: "GPSLatitudeRef", Types: []uint16{TypeASCII}, Values: latitudeRefs,
+		Description: "Whether the latitude is north or south"},
+	{ID: 2, IFD: "GPS", Name: "GPSLatitude", Types: []uint16{TypeRational}, Count: 3, format: formatDMS,
+		Description: "Latitude, as degrees, minutes and seconds"},
+	{ID: 3, IFD: "GPS", Name: "GPSLongitudeRef", Types: []uint16{TypeASCII}, Values: longitudeRefs,
+		Description: "Whether the longitude is east or west"},
+	{ID: 4, IFD: "GPS", Name: "GPSLongitude", Types: []uint16{TypeRational}, Count: 3, format: formatDMS,
+		Description: "Longitude, as degrees, minutes and seconds"},
+	{ID: 5, IFD: "GPS", Name: "GPSAltitudeRef", Types: []uint16{TypeByte}, Count: 1,
+		Values: map[string]string{
+			"0": "Sea level",
+			"1": "Sea level reference (negative value)",
+		},
+		Description: "Whether the altitude is above or below sea level"},
+	{ID: 6, IFD: "GPS", Name: "GPSAltitude", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Altitude, in meters"},
+	{ID: 7, IFD: "GPS", Name: "GPSTimeStamp", Types: []uint16{TypeRational}, Count: 3,
+		format: func(t Tag) string {
+			r := t.rationalToRational()
+			return fmt.Sprintf("%02.0f:%02.0f:%02.0fZ", r[0].Float64(), r[1].Float64(), r[2].Float64())
+		},
+		Description: "Time as UTC"},
+	{ID: 8, IFD: "GPS", Name: "GPSSatellites", Types: []uint16{TypeASCII},
+		Description: "Satellites used for measurement"},
+	{ID: 9, IFD: "GPS", Name: "GPSStatus", Types: []uint16{TypeASCII},
+		Values: map[string]string{
+			"A": "Measurement in progress",
+			"V": "Measurement interrupted",
+		},
+		Description: "Status of the GPS receiver"},
+	{ID: 10, IFD: "GPS", Name: "GPSMeasureMode", Types: []uint16{TypeASCII},
+		Values: map[string]string{
+			"2": "2-dimensional measurement",
+			"3": "3-dimensional measurement",
+		},
+		Description: "GPS measurement mode"},
+	{ID: 11, IFD: "GPS", Name: "GPSDOP", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Data degree of precision"},
+	{ID: 12, IFD: "GPS", Name: "GPSSpeedRef", Types: []uint16{TypeASCII},
+		Values: map[string]string{
+			"K": "Kilometers per hour",
+			"M": "Miles per hour",
+			"N": "Knots",
+		},
+		Description: "Unit of GPSSpeed"},
+	{ID: 13, IFD: "GPS", Name: "GPSSpeed", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Speed of the GPS receiver"},
+	{ID: 14, IFD: "GPS", Name: "GPSTrackRef", Types: []uint16{TypeASCII}, Values: directionRefs,
+		Description: "Reference for the direction of movement"},
+	{ID: 15, IFD: "GPS", Name: "GPSTrack", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Direction of movement, in degrees"},
+	{ID: 16, IFD: "GPS", Name: "GPSImgDirectionRef", Types: []uint16{TypeASCII}, Values: directionRefs,
+		Description: "Reference for the direction of the image"},
+	{ID: 17, IFD: "GPS", Name: "GPSImgDirection", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Direction of the image when captured, in degrees"},
+	{ID: 18, IFD: "GPS", Name: "GPSMapDatum", Types: []uint16{TypeASCII},
+		Description: "Geodetic survey data used"},
+	{ID: 19, IFD: "GPS", Name: "GPSDestLatitudeRef", Types: []uint16{TypeASCII}, Values: latitudeRefs,
+		Description: "Whether the latitude of the destination point is north or south"},
+	{ID: 20, IFD: "GPS", Name: "GPSDestLatitude", Types: []uint16{TypeRational}, Count: 3, format: formatDMS,
+		Description: "Latitude of the destination point"},
+	{ID: 21, IFD: "GPS", Name: "GPSDestLongitudeRef", Types: []uint16{TypeASCII}, Values: longitudeRefs,
+		Description: "Whether the longitude of the destination point is east or west"},
+	{ID: 22, IFD: "GPS", Name: "GPSDestLongitude", Types: []uint16{TypeRational}, Count: 3, format: formatDMS,
+		Description: "Longitude of the destination point"},
+	{ID: 23, IFD: "GPS", Name: "GPSDestBearingRef", Types: []uint16{TypeASCII}, Values: directionRefs,
+		Description: "Reference for the bearing to the destination point"},
+	{ID: 24, IFD: "GPS", Name: "GPSDestBearing", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Bearing to the destination point, in degrees"},
+	{ID: 25, IFD: "GPS", Name: "GPSDestDistanceRef", Types: []uint16{TypeASCII},
+		Values: map[string]string{
+			"K": "Kilometers",
+			"M": "Miles",
+			"N": "Nautical miles",
+		},
+		Description: "Unit of GPSDestDistance"},
+	{ID: 26, IFD: "GPS", Name: "GPSDestDistance", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Distance to the destination point"},
+	{ID: 27, IFD: "GPS", Name: "GPSProcessingMethod", Types: []uint16{TypeUndefined},
+		Description: "Name of the method used for location finding, prefixed by its character code",
+		format:      formatComment},
+	{ID: 28, IFD: "GPS", Name: "GPSAreaInformation", Types: []uint16{TypeUndefined},
+		Description: "Name of the GPS area, prefixed by its character code",
+		format:      formatComment},
+	{ID: 29, IFD: "GPS", Name: "GPSDateStamp", Types: []uint16{TypeASCII},
+		Description: "Date as UTC"},
+	{ID: 30, IFD: "GPS", Name: "GPSDifferential", Types: []uint16{TypeShort}, Count: 1,
+		Description: "Whether differential correction is applied"},
+	{ID: 31, IFD: "GPS", Name: "GPSHPositioningError", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Horizontal positioning error, in meters"},
+}
+
+// formatDMS formats a position recorded as degrees, minutes and seconds
+func formatDMS(t Tag) string {
+	return newCoordinate(t.rationalToRational()).String()
 }
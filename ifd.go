@@ -6,81 +6,334 @@ package nifuda
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"strconv"
 	"strings"
 )
 
+// IFD is an Image File Directory with its raw entries, whether or not nifuda knows how to decode them.
+//
 // An Image File Directory (IFD) consists of a 2-byte count of the number of directory entries, followed by a
 // sequence of 12-byte field entries, followed by a 4-byte offset of the next IFD (or 0 if none).
 //
 // There must be at least 1 IFD in a TIFF file and each IFD must have at least one entry.
 //
+// BigTIFF IFDs use an 8-byte count, 20-byte field entries and an 8-byte offset of the next IFD.
+//
 // Each TIFF field has an associated Count.
 // This means that all fields are actually one-dimensional arrays, even though most fields contain only a single value.
-type ifd struct {
-	next    uint32   // offset in bytes to the next IFD, from the start of the file. 0 if none
-	entries uint16   // number of directory entries
-	tags    []ifdTag // list of undecoded tags
+type IFD struct {
+	Name   string // name of the IFD, as used as key in Exif.IFDs
+	Offset uint64 // offset in bytes of the IFD, from the start of the TIFF header
+	Next   uint64 // offset in bytes to the next IFD, from the start of the TIFF header. 0 if none
+	Tags   []Tag  // list of undecoded tags, in the order they appear in the file
+}
+
+// Tag is an undecoded IFD entry.
+type Tag struct {
+	ID    uint16 // tag identifier
+	Type  uint16 // tiff type identifier
+	Count uint64 // the number of values in data
+	Data  []byte // undecoded payload for tag
+
+	bo     binary.ByteOrder // byte order used to encode data
+	offset uint64           // offset in bytes of data from the start of the TIFF header, 0 if recorded in the entry
+}
+
+// Tag returns the first entry of the IFD with the given identifier.
+func (d *IFD) Tag(id uint16) (Tag, bool) {
+	for _, t := range d.Tags {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Tag{}, false
+}
+
+// ByteOrder returns the byte order used to encode the tag payload.
+func (it Tag) ByteOrder() binary.ByteOrder {
+	return it.bo
+}
+
+// TypeName returns the name of the tiff type of the tag, as defined in TIFF Revision 6.0.
+func (it Tag) TypeName() string {
+	return tiffTypes[it.Type].name
+}
+
+// Value returns the values of the tag decoded according to its tiff type:
+//   - ASCII as a string
+//   - BYTE and UNDEFINED as a []byte
+//   - SHORT as a []uint16, LONG and IFD as a []uint32, LONG8 and IFD8 as a []uint64
+//   - SBYTE as a []int8, SSHORT as a []int16, SLONG as a []int32, SLONG8 as a []int64
+//   - RATIONAL as a []Rational, SRATIONAL as a []SRational
+//   - FLOAT as a []float32, DOUBLE as a []float64
+//
+// This allows to decode tags unknown to nifuda, for example private tags.
+func (it Tag) Value() interface{} {
+	switch it.Type {
+	case TypeASCII:
+		return it.asciiToString()
+	case TypeByte, TypeUndefined:
+		return it.Data
+	case TypeShort:
+		return it.shortToUint16()
+	case TypeLong, TypeIFD:
+		return it.longToUint32()
+	case TypeLong8, TypeIFD8:
+		return it.long8ToUint64()
+	case TypeSByte:
+		return it.sbyteToInt8()
+	case TypeSShort:
+		return it.sshortToInt16()
+	case TypeSLong:
+		return it.slongToInt32()
+	case TypeSLong8:
+		return it.slong8ToInt64()
+	case TypeRational:
+		return it.rationalToRational()
+	case TypeSRational:
+		return it.srationalToSRational()
+	case TypeFloat:
+		return it.floatToFloat32()
+	case TypeDouble:
+		return it.doubleToFloat64()
+	}
+	return nil
+}
+
+// String returns the values of the tag as text: ASCII and UNDEFINED values as is, other values separated by spaces.
+func (it Tag) String() string {
+	switch it.Type {
+	case TypeASCII:
+		return it.asciiToString()
+	case TypeUndefined:
+		return it.undefinedToString()
+	}
+	return strings.Trim(fmt.Sprint(it.Value()), "[]")
 }
 
-type ifdTag struct {
-	id       uint16 // tag identifier
-	tiffType uint16 // tiff type idendifier
-	count    uint32 // the number of values in data
-	data     []byte // undecoded payload for tag
+// Decoders below never return an empty slice, so that the first value can always be accessed.
+// Values missing from data, due to a wrong type or count, are decoded as zero.
+
+// values returns the number of values of size bytes to be decoded from data.
+func (it Tag) values(size int) int {
+	n := uint64(len(it.Data) / size)
+	if n > it.Count {
+		n = it.Count
+	}
+	if n == 0 {
+		n = 1
+	}
+	return int(n)
 }
 
-func (it ifdTag) byteToInt(bo binary.ByteOrder) []int {
-	b := make([]int, it.count)
-	raw := bytes.NewReader(it.data)
+func (it Tag) byteToInt() []int {
+	b := make([]int, it.values(1))
+	raw := bytes.NewReader(it.Data)
 	var v uint8
 	for i := range b {
-		binary.Read(raw, bo, &v)
+		binary.Read(raw, it.bo, &v)
 		b[i] = int(v)
 	}
 	return b
 }
 
-func (it ifdTag) asciiToString() string {
-	return string(it.data[0 : it.count-1]) // -1 to remove character '\0'
+func (it Tag) sbyteToInt8() []int8 {
+	var v int8
+	b := make([]int8, it.values(1))
+	raw := bytes.NewReader(it.Data)
+	for i := range b {
+		binary.Read(raw, it.bo, &v)
+		b[i] = v
+	}
+	return b
+}
+
+func (it Tag) asciiToString() string {
+	// string ends at the first character '\0'
+	if i := bytes.IndexByte(it.Data, 0); i >= 0 {
+		return string(it.Data[:i])
+	}
+	return string(it.Data)
 }
 
-func (it ifdTag) shortToUint16(bo binary.ByteOrder) []uint16 {
+func (it Tag) shortToUint16() []uint16 {
 	var s uint16
-	S := make([]uint16, it.count)
-	raw := bytes.NewReader(it.data)
+	S := make([]uint16, it.values(2))
+	raw := bytes.NewReader(it.Data)
 	for i := range S {
-		binary.Read(raw, bo, &s)
+		binary.Read(raw, it.bo, &s)
 		S[i] = s
 	}
 	return S
 }
 
-func (it ifdTag) longToUint32(bo binary.ByteOrder) []uint32 {
+func (it Tag) sshortToInt16() []int16 {
+	var s int16
+	S := make([]int16, it.values(2))
+	raw := bytes.NewReader(it.Data)
+	for i := range S {
+		binary.Read(raw, it.bo, &s)
+		S[i] = s
+	}
+	return S
+}
+
+func (it Tag) longToUint32() []uint32 {
 	var l uint32
-	L := make([]uint32, it.count)
-	raw := bytes.NewReader(it.data)
+	L := make([]uint32, it.values(4))
+	raw := bytes.NewReader(it.Data)
+	for i := range L {
+		binary.Read(raw, it.bo, &l)
+		L[i] = l
+	}
+	return L
+}
+
+func (it Tag) slongToInt32() []int32 {
+	var l int32
+	L := make([]int32, it.values(4))
+	raw := bytes.NewReader(it.Data)
+	for i := range L {
+		binary.Read(raw, it.bo, &l)
+		L[i] = l
+	}
+	return L
+}
+
+// uintValues decodes tags recording unsigned integers, whatever the size used to record them.
+// This includes tags which can be recorded either as SHORT or LONG.
+func (it Tag) uintValues() []uint64 {
+	var U []uint64
+	switch it.Type {
+	case TypeByte, TypeUndefined:
+		for _, b := range it.byteToInt() {
+			U = append(U, uint64(b))
+		}
+	case TypeShort:
+		for _, s := range it.shortToUint16() {
+			U = append(U, uint64(s))
+		}
+	case TypeLong, TypeIFD:
+		for _, l := range it.longToUint32() {
+			U = append(U, uint64(l))
+		}
+	case TypeLong8, TypeIFD8:
+		U = it.long8ToUint64()
+	default:
+		U = make([]uint64, 1)
+	}
+	return U
+}
+
+// intValues decodes tags recording signed integers, whatever the size used to record them.
+func (it Tag) intValues() []int64 {
+	var I []int64
+	switch it.Type {
+	case TypeSByte:
+		for _, b := range it.sbyteToInt8() {
+			I = append(I, int64(b))
+		}
+	case TypeSShort:
+		for _, s := range it.sshortToInt16() {
+			I = append(I, int64(s))
+		}
+	case TypeSLong:
+		for _, l := range it.slongToInt32() {
+			I = append(I, int64(l))
+		}
+	case TypeSLong8:
+		I = it.slong8ToInt64()
+	default:
+		for _, u := range it.uintValues() {
+			I = append(I, int64(u))
+		}
+	}
+	return I
+}
+
+// offsetToUint64 decodes tags recording offsets, as LONG or IFD, or LONG8 or IFD8 for BigTIFF
+func (it Tag) offsetToUint64() []uint64 {
+	switch it.Type {
+	case TypeLong, TypeIFD:
+		L := it.longToUint32()
+		O := make([]uint64, len(L))
+		for i, l := range L {
+			O[i] = uint64(l)
+		}
+		return O
+	case TypeLong8, TypeIFD8:
+		return it.long8ToUint64()
+	}
+	return make([]uint64, 1)
+}
+
+func (it Tag) long8ToUint64() []uint64 {
+	var l uint64
+	L := make([]uint64, it.values(8))
+	raw := bytes.NewReader(it.Data)
 	for i := range L {
-		binary.Read(raw, bo, &l)
+		binary.Read(raw, it.bo, &l)
 		L[i] = l
 	}
 	return L
 }
 
-func (it ifdTag) rationalToFloat32(bo binary.ByteOrder) []float32 {
-	var n, d uint32
-	r := make([]float32, it.count)
-	raw := bytes.NewReader(it.data)
+func (it Tag) slong8ToInt64() []int64 {
+	var l int64
+	L := make([]int64, it.values(8))
+	raw := bytes.NewReader(it.Data)
+	for i := range L {
+		binary.Read(raw, it.bo, &l)
+		L[i] = l
+	}
+	return L
+}
+
+func (it Tag) rationalToRational() []Rational {
+	r := make([]Rational, it.values(8))
+	raw := bytes.NewReader(it.Data)
+	for i := range r {
+		binary.Read(raw, it.bo, &r[i].Num)
+		binary.Read(raw, it.bo, &r[i].Den)
+	}
+	return r
+}
+
+func (it Tag) srationalToSRational() []SRational {
+	r := make([]SRational, it.values(8))
+	raw := bytes.NewReader(it.Data)
 	for i := range r {
-		binary.Read(raw, bo, &n)
-		binary.Read(raw, bo, &d)
-		r[i] = float32(n / d)
+		binary.Read(raw, it.bo, &r[i].Num)
+		binary.Read(raw, it.bo, &r[i].Den)
 	}
 	return r
 }
 
-func (it ifdTag) undefinedToString() string {
-	return string(it.data[0:it.count])
+func (it Tag) floatToFloat32() []float32 {
+	var f float32
+	F := make([]float32, it.values(4))
+	raw := bytes.NewReader(it.Data)
+	for i := range F {
+		binary.Read(raw, it.bo, &f)
+		F[i] = f
+	}
+	return F
+}
+
+func (it Tag) doubleToFloat64() []float64 {
+	var d float64
+	D := make([]float64, it.values(8))
+	raw := bytes.NewReader(it.Data)
+	for i := range D {
+		binary.Read(raw, it.bo, &d)
+		D[i] = d
+	}
+	return D
+}
+
+func (it Tag) undefinedToString() string {
+	return string(it.Data)
 }
 
 // Helpers
@@ -3,26 +3,38 @@
 
 package nifuda
 
-import "encoding/binary"
-
-// ImageTags contains tags from first IFD (IFD0).
+// ImageTags contains tags from first IFD (IFD0), also used for tags from IFD1 describing the thumbnail.
 // Fields are defined in order they appeared in chapter 4.6.4 of Exif 2.31
 type ImageTags struct {
 	// A. Tags relating to image data structure
-	ExifIFD             uint32
-	GpsIFD              uint32
-	InteroperabilityIFD uint32
-	//ImageWidth SHORT or LONG :(
-	//ImageLength SHORT or LONG :(
+	ExifIFD                   uint64 `nifuda:"ExifIFDPointer"`
+	GpsIFD                    uint64 `nifuda:"GPSInfoIFDPointer"`
+	InteroperabilityIFD       uint64 `nifuda:"InteroperabilityIFDPointer"`
+	ImageWidth                uint32 // recorded as SHORT or LONG, or LONG8 in BigTIFF files
+	ImageLength               uint32 // recorded as SHORT or LONG, or LONG8 in BigTIFF files
 	BitsPerSample             uint16
-	Compression               string
-	PhotometricInterpretation string
+	Compression               Compression
+	PhotometricInterpretation PhotometricInterpretation
+	Orientation               Orientation
 	SamplesPerPixel           uint16
-	PlanarConfiguration       string
-	YCbCrPositioning          string
-	ResolutionUnit            string
+	PlanarConfiguration       PlanarConfiguration
+	YCbCrSubSampling          []uint16 // horizontal and vertical subsampling factors of chrominance
+	YCbCrPositioning          YCbCrPositioning
+	XResolution               Rational
+	YResolution               Rational
+	ResolutionUnit            ResolutionUnit
 	// B. Tags relating to recording offset
+	StripOffsets                []uint64 // recorded as SHORT or LONG, or LONG8 in BigTIFF files
+	RowsPerStrip                uint32   // recorded as SHORT or LONG, or LONG8 in BigTIFF files
+	StripByteCounts             []uint64 // recorded as SHORT or LONG, or LONG8 in BigTIFF files
+	JPEGInterchangeFormat       uint64   // recorded as LONG, or LONG8 in BigTIFF files
+	JPEGInterchangeFormatLength uint64   // recorded as LONG, or LONG8 in BigTIFF files
 	// C. Tags relating to image data characteristics
+	TransferFunction      []uint16
+	WhitePoint            []Rational
+	PrimaryChromaticities []Rational
+	YCbCrCoefficients     []Rational
+	ReferenceBlackWhite   []Rational
 	// D. Other tags
 	DateTime         string
 	ImageDescription string
@@ -33,71 +45,211 @@ type ImageTags struct {
 	Copyright        string
 }
 
-func parseIFDTagsAsImageTags(ifd *ifd, bo binary.ByteOrder) ImageTags {
-	var t ImageTags
-
-	for _, ifdtag := range ifd.tags {
-		switch ifdtag.id {
-		case 34665: // Exif IFD
-			t.ExifIFD = ifdtag.longToUint32(bo)[0]
-		case 34853: // GPS IFD
-			t.GpsIFD = ifdtag.longToUint32(bo)[0]
-		case 258: // BitsPerSample
-			t.BitsPerSample = ifdtag.shortToUint16(bo)[0]
-		case 259: // Compression
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 1:
-				t.Compression = "uncompressed"
-			case 6:
-				t.Compression = "JPEG compression"
-			}
-		case 262: // PhotometricInterpretation
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 2:
-				t.PhotometricInterpretation = "RGB"
-			case 6:
-				t.PhotometricInterpretation = "YCbCr"
-			}
-		case 274: // Orientation
-		case 277: // SamplesPerPixel
-			t.SamplesPerPixel = ifdtag.shortToUint16(bo)[0]
-		case 284: // PlanarConfiguration
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 1:
-				t.PlanarConfiguration = "chunky format"
-			case 2:
-				t.PlanarConfiguration = "planar format"
-			}
-		case 531: // YCbCrPositioning
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 1:
-				t.YCbCrPositioning = "centered"
-			case 2:
-				t.YCbCrPositioning = "co-sited"
-			}
-		case 296: // ResolutionUnit
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 2:
-				t.ResolutionUnit = "inches"
-			case 3:
-				t.ResolutionUnit = "centimeters"
-			}
-		case 306: // DateTime
-			t.DateTime = ifdtag.asciiToString()
-		case 270: // ImageDescription
-			t.ImageDescription = ifdtag.asciiToString()
-		case 271: // Make
-			t.Make = ifdtag.asciiToString()
-		case 272: // Model
-			t.Model = ifdtag.asciiToString()
-		case 305: // Software
-			t.Software = ifdtag.asciiToString()
-		case 315: // Artist
-			t.Artist = ifdtag.asciiToString()
-		case 33432: // Copyright
-			t.Copyright = ifdtag.asciiToString()
-		}
-	}
-
-	return t
+// Compression is the compression scheme used for the image data.
+type Compression uint16
+
+// Compression schemes defined by Exif 2.31, completed by schemes commonly found in RAW files
+const (
+	CompressionUncompressed  Compression = 1
+	CompressionJPEGThumbnail Compression = 6 // JPEG compression, used by Exif for thumbnails only
+	CompressionJPEG          Compression = 7
+	CompressionDeflate       Compression = 8
+	CompressionPackBits      Compression = 32773
+	CompressionNikonNEF      Compression = 34713
+)
+
+func (v Compression) String() string {
+	return describeValue("IFD0", 259, "Compression", uint16(v))
+}
+
+// PhotometricInterpretation is the color space of the image data.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type PhotometricInterpretation uint16
+
+// Photometric interpretations defined by TIFF Revision 6.0, completed by the CFA interpretation found in RAW files
+const (
+	PhotometricWhiteIsZero PhotometricInterpretation = 0
+	PhotometricBlackIsZero PhotometricInterpretation = 1
+	PhotometricRGB         PhotometricInterpretation = 2
+	PhotometricYCbCr       PhotometricInterpretation = 6
+	PhotometricCFA         PhotometricInterpretation = 32803
+)
+
+func (v PhotometricInterpretation) String() string {
+	return describeValue("IFD0", 262, "PhotometricInterpretation", uint16(v))
+}
+
+// PlanarConfiguration indicates whether pixel components are recorded in chunky or planar format.
+type PlanarConfiguration uint16
+
+// Planar configurations defined by Exif 2.31
+const (
+	PlanarConfigurationChunky PlanarConfiguration = 1
+	PlanarConfigurationPlanar PlanarConfiguration = 2
+)
+
+func (v PlanarConfiguration) String() string {
+	return describeValue("IFD0", 284, "PlanarConfiguration", uint16(v))
+}
+
+// YCbCrPositioning is the position of chrominance components in relation to the luminance component.
+type YCbCrPositioning uint16
+
+// YCbCr positionings defined by Exif 2.31
+const (
+	YCbCrCentered YCbCrPositioning = 1
+	YCbCrCoSited  YCbCrPositioning = 2
+)
+
+func (v YCbCrPositioning) String() string {
+	return describeValue("IFD0", 531, "YCbCrPositioning", uint16(v))
+}
+
+// ResolutionUnit is the unit for measuring XResolution and YResolution.
+type ResolutionUnit uint16
+
+// Resolution units defined by Exif 2.31
+const (
+	ResolutionUnitInches      ResolutionUnit = 2
+	ResolutionUnitCentimeters ResolutionUnit = 3
+)
+
+func (v ResolutionUnit) String() string {
+	return describeValue("IFD0", 296, "ResolutionUnit", uint16(v))
+}
+
+// Offsets are recorded as LONG or IFD, or as LONG8 or IFD8 in BigTIFF files
+var offsetTypes = []uint16{TypeLong, TypeIFD, TypeLong8, TypeIFD8}
+
+// Dimensions, offsets and byte counts of image data are recorded as SHORT or LONG, or as LONG8 in BigTIFF files
+var (
+	sizeTypes = []uint16{TypeShort, TypeLong, TypeLong8}
+	longTypes = []uint16{TypeLong, TypeLong8}
+)
+
+var imageTagDefs = []TagDef{
+	// A. Tags relating to image data structure
+	{ID: 254, IFD: "IFD0", Name: "NewSubfileType", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Kind of data contained in the subfile"},
+	{ID: 256, IFD: "IFD0", Name: "ImageWidth", Types: sizeTypes, Count: 1,
+		Description: "Number of pixels per row"},
+	{ID: 257, IFD: "IFD0", Name: "ImageLength", Types: sizeTypes, Count: 1,
+		Description: "Number of rows of pixels"},
+	{ID: 258, IFD: "IFD0", Name: "BitsPerSample", Types: []uint16{TypeShort},
+		Description: "Number of bits per image component"},
+	{ID: 259, IFD: "IFD0", Name: "Compression", Types: []uint16{TypeShort}, Count: 1,
+		// in addition to the values defined by Exif 2.31, values commonly found in RAW files are supported
+		Values: map[string]string{
+			"1":     "uncompressed",
+			"6":     "JPEG compression",
+			"7":     "JPEG",
+			"8":     "Adobe Deflate",
+			"32773": "PackBits",
+			"34713": "Nikon NEF Compressed",
+		},
+		Description: "Compression scheme used for the image data"},
+	{ID: 262, IFD: "IFD0", Name: "PhotometricInterpretation", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0":     "WhiteIsZero",
+			"1":     "BlackIsZero",
+			"2":     "RGB",
+			"6":     "YCbCr",
+			"32803": "CFA",
+		},
+		Description: "Pixel composition"},
+	{ID: 274, IFD: "IFD0", Name: "Orientation", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"1": "horizontal (normal)",
+			"2": "mirror horizontal",
+			"3": "rotate 180",
+			"4": "mirror vertical",
+			"5": "mirror horizontal and rotate 270 CW",
+			"6": "rotate 90 CW",
+			"7": "mirror horizontal and rotate 90 CW",
+			"8": "rotate 270 CW",
+		},
+		Description: "Orientation of the image in terms of rows and columns"},
+	{ID: 277, IFD: "IFD0", Name: "SamplesPerPixel", Types: []uint16{TypeShort}, Count: 1,
+		Description: "Number of components per pixel"},
+	{ID: 284, IFD: "IFD0", Name: "PlanarConfiguration", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"1": "chunky format",
+			"2": "planar format",
+		},
+		Description: "Whether pixel components are recorded in chunky or planar format"},
+	{ID: 530, IFD: "IFD0", Name: "YCbCrSubSampling", Types: []uint16{TypeShort}, Count: 2,
+		Values: map[string]string{
+			"2 1": "YCbCr4:2:2",
+			"2 2": "YCbCr4:2:0",
+		},
+		Description: "Sampling ratio of chrominance components in relation to the luminance component"},
+	{ID: 531, IFD: "IFD0", Name: "YCbCrPositioning", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"1": "centered",
+			"2": "co-sited",
+		},
+		Description: "Position of chrominance components in relation to the luminance component"},
+	{ID: 282, IFD: "IFD0", Name: "XResolution", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Number of pixels per ResolutionUnit in the width direction"},
+	{ID: 283, IFD: "IFD0", Name: "YResolution", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Number of pixels per ResolutionUnit in the height direction"},
+	{ID: 296, IFD: "IFD0", Name: "ResolutionUnit", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"2": "inches",
+			"3": "centimeters",
+		},
+		Description: "Unit for measuring XResolution and YResolution"},
+	{ID: 34665, IFD: "IFD0", Name: "ExifIFDPointer", Types: offsetTypes, Count: 1,
+		Description: "Offset of the Exif IFD"},
+	{ID: 34853, IFD: "IFD0", Name: "GPSInfoIFDPointer", Types: offsetTypes, Count: 1,
+		Description: "Offset of the GPS IFD"},
+	{ID: 40965, IFD: "IFD0", Name: "InteroperabilityIFDPointer", Types: offsetTypes, Count: 1,
+		Description: "Offset of the Interoperability IFD, normally recorded in the Exif IFD"},
+	{ID: 330, IFD: "IFD0", Name: "SubIFDs", Types: offsetTypes,
+		Description: "Offsets of child IFDs"},
+	// B. Tags relating to recording offset
+	{ID: 273, IFD: "IFD0", Name: "StripOffsets", Types: sizeTypes,
+		Description: "Offset of each strip of image data"},
+	{ID: 278, IFD: "IFD0", Name: "RowsPerStrip", Types: sizeTypes, Count: 1,
+		Description: "Number of rows per strip"},
+	{ID: 279, IFD: "IFD0", Name: "StripByteCounts", Types: sizeTypes,
+		Description: "Number of bytes in each strip, after compression"},
+	{ID: 322, IFD: "IFD0", Name: "TileWidth", Types: sizeTypes, Count: 1,
+		Description: "Number of columns in each tile"},
+	{ID: 323, IFD: "IFD0", Name: "TileLength", Types: sizeTypes, Count: 1,
+		Description: "Number of rows in each tile"},
+	{ID: 324, IFD: "IFD0", Name: "TileOffsets", Types: longTypes,
+		Description: "Offset of each tile of image data"},
+	{ID: 325, IFD: "IFD0", Name: "TileByteCounts", Types: sizeTypes,
+		Description: "Number of bytes in each tile, after compression"},
+	{ID: 513, IFD: "IFD0", Name: "JPEGInterchangeFormat", Types: longTypes, Count: 1,
+		Description: "Offset of the JPEG compressed thumbnail"},
+	{ID: 514, IFD: "IFD0", Name: "JPEGInterchangeFormatLength", Types: longTypes, Count: 1,
+		Description: "Number of bytes of the JPEG compressed thumbnail"},
+	// C. Tags relating to image data characteristics
+	{ID: 301, IFD: "IFD0", Name: "TransferFunction", Types: []uint16{TypeShort}, // 3 * 256 values for Exif, any count allowed by TIFF
+		Description: "Transfer function of the image, in tabular style"},
+	{ID: 318, IFD: "IFD0", Name: "WhitePoint", Types: []uint16{TypeRational}, Count: 2,
+		Description: "Chromaticity of the white point of the image"},
+	{ID: 319, IFD: "IFD0", Name: "PrimaryChromaticities", Types: []uint16{TypeRational}, Count: 6,
+		Description: "Chromaticities of the three primary colors of the image"},
+	{ID: 529, IFD: "IFD0", Name: "YCbCrCoefficients", Types: []uint16{TypeRational}, Count: 3,
+		Description: "Matrix coefficients for the transformation from RGB to YCbCr"},
+	{ID: 532, IFD: "IFD0", Name: "ReferenceBlackWhite", Types: []uint16{TypeRational}, Count: 6,
+		Description: "Reference black point and reference white point values"},
+	// D. Other tags
+	{ID: 306, IFD: "IFD0", Name: "DateTime", Types: []uint16{TypeASCII},
+		Description: "Date and time of image creation"},
+	{ID: 270, IFD: "IFD0", Name: "ImageDescription", Types: []uint16{TypeASCII},
+		Description: "Title of the image"},
+	{ID: 271, IFD: "IFD0", Name: "Make", Types: []uint16{TypeASCII},
+		Description: "Manufacturer of the recording equipment"},
+	{ID: 272, IFD: "IFD0", Name: "Model", Types: []uint16{TypeASCII},
+		Description: "Model name or number of the recording equipment"},
+	{ID: 305, IFD: "IFD0", Name: "Software", Types: []uint16{TypeASCII},
+		Description: "Name and version of the software used to generate the image"},
+	{ID: 315, IFD: "IFD0", Name: "Artist", Types: []uint16{TypeASCII},
+		Description: "Name of the camera owner, photographer or image creator"},
+	{ID: 33432, IFD: "IFD0", Name: "Copyright", Types: []uint16{TypeASCII},
+		Description: "Copyright notice"},
 }
@@ -25,15 +25,23 @@ func main() {
 		log.Fatal(err)
 	}
 
-	v := reflect.ValueOf(x.Image)
-	vt := v.Type()
-	for i := 0; i < vt.NumField(); i++ {
-		fmt.Printf("Image.%-30s   %v\n", vt.Field(i).Name, v.Field(i).Interface())
-	}
+	printFields(x, "Image", "IFD0", x.Image)
+	printFields(x, "Photo", "Exif", x.Photo)
+}
 
-	v = reflect.ValueOf(x.Photo)
-	vt = v.Type()
+// printFields prints the fields of tags decoded from ifd, or "-" for the tags not recorded
+func printFields(x *nifuda.Exif, prefix string, ifd string, tags interface{}) {
+	v := reflect.ValueOf(tags)
+	vt := v.Type()
 	for i := 0; i < vt.NumField(); i++ {
-		fmt.Printf("Photo.%-30s   %v\n", vt.Field(i).Name, v.Field(i).Interface())
+		name := vt.Field(i).Name
+		if s, ok := vt.Field(i).Tag.Lookup("nifuda"); ok {
+			name = s
+		}
+		var value interface{} = "-"
+		if x.Has(ifd, name) {
+			value = v.Field(i).Interface()
+		}
+		fmt.Printf("%s.%-30s   %v\n", prefix, vt.Field(i).Name, value)
 	}
 }
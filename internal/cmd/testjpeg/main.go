@@ -9,6 +9,7 @@
 package main
 
 import (
+	"io/ioutil"
 	"log"
 	"os"
 )
@@ -38,6 +39,16 @@ func app0() []byte {
 	}
 }
 
+func app1(tiff []byte) []byte {
+	length := 2 + 6 + len(tiff)
+	s := []byte{
+		0xff, 0xe1,
+		byte(length >> 8), byte(length), // segment length
+		0x45, 0x78, 0x69, 0x66, 0x00, 0x00, // Exif\0\0
+	}
+	return append(s, tiff...)
+}
+
 func createFile(filepath string, data ...[]byte) {
 	f, err := os.Create(filepath)
 	if err != nil {
@@ -57,4 +68,11 @@ func main() {
 	createFile("data/nosoi.jpg", eoi())
 
 	createFile("data/minimal.jpg", soi(), app0(), eoi())
+
+	// requires data/thumbnail.tif created by testtiff
+	tiff, err := ioutil.ReadFile("data/thumbnail.tif")
+	if err != nil {
+		log.Fatal(err)
+	}
+	createFile("data/thumbnail.jpg", soi(), app0(), app1(tiff), eoi())
 }
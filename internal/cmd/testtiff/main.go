@@ -9,6 +9,8 @@
 package main
 
 import (
+	"bytes"
+	"encoding/binary"
 	"log"
 	"os"
 )
@@ -35,6 +37,193 @@ func ifd() []byte {
 	}
 }
 
+// entry is a field entry to be written by build
+type entry struct {
+	id    uint16
+	typ   uint16
+	count uint32
+	value []byte // value already encoded, written inline or after the IFD depending of its size
+	ptrs  []*dir // if not nil, value is replaced by the offsets of the pointed IFDs
+	blob  []byte // if not nil, value is replaced by the offset of the blob, written at the end of the file
+	embed *dir   // if not nil, value is the IFD itself with its values, as for the MakerNotes of some cameras
+}
+
+// dir is an IFD to be written by build
+type dir struct {
+	entries []entry
+	next    *dir
+
+	offset uint64 // computed by build
+	size   uint64 // computed by build, including values written after the IFD
+}
+
+// layout describes sizes in bytes used by classic TIFF or BigTIFF
+type layout struct {
+	version uint16
+	header  uint64 // size of the header
+	count   uint64 // size of the number of entries
+	entry   uint64 // size of an entry
+	offset  uint64 // size of an offset, which is also the maximum size for an inline value
+}
+
+var (
+	classic = layout{version: 42, header: 8, count: 2, entry: 12, offset: 4}
+	big     = layout{version: 43, header: 16, count: 8, entry: 20, offset: 8}
+)
+
+// build lays out a complete TIFF file from a list of IFDs, the first one being IFD0
+func build(bo binary.ByteOrder, dirs ...*dir) []byte {
+	return buildWithLayout(classic, bo, dirs...)
+}
+
+// buildBig lays out a complete BigTIFF file from a list of IFDs, the first one being IFD0
+func buildBig(bo binary.ByteOrder, dirs ...*dir) []byte {
+	return buildWithLayout(big, bo, dirs...)
+}
+
+func buildWithLayout(l layout, bo binary.ByteOrder, dirs ...*dir) []byte {
+	// encoding of integers whose size depends of the layout
+	encode := func(size uint64, v uint64) []byte {
+		buf := make([]byte, 8)
+		bo.PutUint64(buf, v)
+		if size == 8 {
+			return buf
+		}
+		buf = make([]byte, size)
+		switch size {
+		case 2:
+			bo.PutUint16(buf, uint16(v))
+		case 4:
+			bo.PutUint32(buf, uint32(v))
+		}
+		return buf
+	}
+
+	// size in bytes of the encoded value of an entry
+	size := func(e *entry) uint64 {
+		if e.ptrs != nil {
+			return l.offset * uint64(len(e.ptrs))
+		}
+		if e.embed != nil {
+			return 0
+		}
+		return uint64(len(e.value))
+	}
+
+	// first pass to compute offsets
+	pos := l.header
+	valueOffsets := make(map[*entry]uint64)
+	for _, d := range dirs {
+		d.offset = pos
+		pos += l.count + l.entry*uint64(len(d.entries)) + l.offset
+		for i := range d.entries {
+			e := &d.entries[i]
+			if size(e) > l.offset {
+				valueOffsets[e] = pos
+				pos += size(e) + size(e)%2
+			}
+		}
+		d.size = pos - d.offset
+	}
+	blobOffsets := make(map[*entry]uint64)
+	for _, d := range dirs {
+		for i := range d.entries {
+			e := &d.entries[i]
+			if e.blob != nil {
+				blobOffsets[e] = pos
+				pos += uint64(len(e.blob))
+			}
+		}
+	}
+
+	// second pass to write
+	buf := new(bytes.Buffer)
+	if bo == binary.LittleEndian {
+		buf.WriteString("II")
+	} else {
+		buf.WriteString("MM")
+	}
+	binary.Write(buf, bo, l.version)
+	if l.version == 43 {
+		binary.Write(buf, bo, uint16(8)) // bytesize of offsets
+		binary.Write(buf, bo, uint16(0)) // reserved
+	}
+	buf.Write(encode(l.offset, dirs[0].offset))
+	for _, d := range dirs {
+		buf.Write(encode(l.count, uint64(len(d.entries))))
+		var values []byte
+		for i := range d.entries {
+			e := &d.entries[i]
+			binary.Write(buf, bo, e.id)
+			binary.Write(buf, bo, e.typ)
+			if e.embed != nil {
+				buf.Write(encode(l.offset, e.embed.size))
+			} else {
+				buf.Write(encode(l.offset, uint64(e.count)))
+			}
+
+			value := e.value
+			if e.ptrs != nil {
+				value = nil
+				for _, p := range e.ptrs {
+					value = append(value, encode(l.offset, p.offset)...)
+				}
+			}
+			switch {
+			case e.embed != nil:
+				buf.Write(encode(l.offset, e.embed.offset))
+			case e.blob != nil:
+				buf.Write(encode(l.offset, blobOffsets[e]))
+			case uint64(len(value)) > l.offset:
+				buf.Write(encode(l.offset, valueOffsets[e]))
+				values = append(values, value...)
+				if len(value)%2 == 1 {
+					values = append(values, 0)
+				}
+			default:
+				v := make([]byte, l.offset)
+				copy(v, value)
+				buf.Write(v)
+			}
+		}
+		if d.next != nil {
+			buf.Write(encode(l.offset, d.next.offset))
+		} else {
+			buf.Write(encode(l.offset, 0))
+		}
+		buf.Write(values)
+	}
+	for _, d := range dirs {
+		for i := range d.entries {
+			buf.Write(d.entries[i].blob)
+		}
+	}
+	return buf.Bytes()
+}
+
+// helpers to encode values
+func ascii(s string) []byte {
+	return append([]byte(s), 0)
+}
+
+func short(bo binary.ByteOrder, v ...uint16) []byte {
+	buf := new(bytes.Buffer)
+	binary.Write(buf, bo, v)
+	return buf.Bytes()
+}
+
+func long(bo binary.ByteOrder, v ...uint32) []byte {
+	buf := new(bytes.Buffer)
+	binary.Write(buf, bo, v)
+	return buf.Bytes()
+}
+
+func long8(bo binary.ByteOrder, v ...uint64) []byte {
+	buf := new(bytes.Buffer)
+	binary.Write(buf, bo, v)
+	return buf.Bytes()
+}
+
 func createFile(filepath string, data ...[]byte) {
 	f, err := os.Create(filepath)
 	if err != nil {
@@ -78,4 +267,155 @@ func main() {
 	ifd0[17] = 0x26
 	createFile("data/wrong_ifd1.tif", ifh(), ifd0, ifd()[:4])
 
+	// IFD1 hosting a (fake) JPEG thumbnail
+	var bo binary.ByteOrder = binary.BigEndian
+	thumbnail := []byte{0xff, 0xd8, 0xff, 0xd9}
+	dir1 := &dir{entries: []entry{
+		{id: 256, typ: 4, count: 1, value: long(bo, 160)},                    // ImageWidth
+		{id: 257, typ: 3, count: 1, value: short(bo, 120)},                   // ImageLength
+		{id: 259, typ: 3, count: 1, value: short(bo, 6)},                     // Compression
+		{id: 513, typ: 4, count: 1, blob: thumbnail},                         // JPEGInterchangeFormat
+		{id: 514, typ: 4, count: 1, value: long(bo, uint32(len(thumbnail)))}, // JPEGInterchangeFormatLength
+	}}
+	dir0 := &dir{entries: []entry{
+		{id: 256, typ: 3, count: 1, value: short(bo, 640)},  // ImageWidth
+		{id: 257, typ: 4, count: 1, value: long(bo, 480)},   // ImageLength
+		{id: 271, typ: 2, count: 7, value: ascii("nifuda")}, // Make
+	}, next: dir1}
+	createFile("data/thumbnail.tif", build(bo, dir0, dir1))
+
+	// Interoperability IFD pointed from the Exif IFD
+	interop := &dir{entries: []entry{
+		{id: 1, typ: 2, count: 4, value: ascii("R98")},           // InteroperabilityIndex
+		{id: 2, typ: 7, count: 4, value: []byte("0100")},         // InteroperabilityVersion
+		{id: 4096, typ: 2, count: 10, value: ascii("Exif JPEG")}, // RelatedImageFileFormat
+		{id: 4097, typ: 3, count: 1, value: short(bo, 640)},      // RelatedImageWidth
+		{id: 4098, typ: 4, count: 1, value: long(bo, 480)},       // RelatedImageLength
+	}}
+	exif := &dir{entries: []entry{
+		{id: 36864, typ: 7, count: 4, value: []byte("0231")}, // ExifVersion
+		{id: 40965, typ: 4, count: 1, ptrs: []*dir{interop}}, // Interoperability IFD
+	}}
+	dir0 = &dir{entries: []entry{
+		{id: 271, typ: 2, count: 7, value: ascii("nifuda")}, // Make
+		{id: 34665, typ: 4, count: 1, ptrs: []*dir{exif}},   // Exif IFD
+	}}
+	createFile("data/interop.tif", build(bo, dir0, exif, interop))
+
+	// SubIFDs as found in RAW files: a tiled full resolution image with a nested stripped preview, and a JPEG preview
+	nested := &dir{entries: []entry{
+		{id: 254, typ: 4, count: 1, value: long(bo, 1)},            // NewSubfileType
+		{id: 256, typ: 3, count: 1, value: short(bo, 160)},         // ImageWidth
+		{id: 257, typ: 3, count: 1, value: short(bo, 120)},         // ImageLength
+		{id: 259, typ: 3, count: 1, value: short(bo, 1)},           // Compression
+		{id: 273, typ: 4, count: 2, value: long(bo, 1000, 29800)},  // StripOffsets
+		{id: 278, typ: 3, count: 1, value: short(bo, 60)},          // RowsPerStrip
+		{id: 279, typ: 4, count: 2, value: long(bo, 28800, 28800)}, // StripByteCounts
+	}}
+	raw := &dir{entries: []entry{
+		{id: 254, typ: 4, count: 1, value: long(bo, 0)},                      // NewSubfileType
+		{id: 256, typ: 4, count: 1, value: long(bo, 6048)},                   // ImageWidth
+		{id: 257, typ: 4, count: 1, value: long(bo, 4024)},                   // ImageLength
+		{id: 259, typ: 3, count: 1, value: short(bo, 34713)},                 // Compression
+		{id: 322, typ: 3, count: 1, value: short(bo, 256)},                   // TileWidth
+		{id: 323, typ: 3, count: 1, value: short(bo, 256)},                   // TileLength
+		{id: 324, typ: 4, count: 3, value: long(bo, 100000, 200000, 300000)}, // TileOffsets
+		{id: 325, typ: 4, count: 3, value: long(bo, 100000, 100000, 100000)}, // TileByteCounts
+		{id: 330, typ: 4, count: 1, ptrs: []*dir{nested}},                    // SubIFDs
+	}}
+	preview := &dir{entries: []entry{
+		{id: 254, typ: 4, count: 1, value: long(bo, 1)},      // NewSubfileType
+		{id: 259, typ: 3, count: 1, value: short(bo, 6)},     // Compression
+		{id: 513, typ: 4, count: 1, value: long(bo, 50000)},  // JPEGInterchangeFormat
+		{id: 514, typ: 4, count: 1, value: long(bo, 123456)}, // JPEGInterchangeFormatLength
+	}}
+	dir0 = &dir{entries: []entry{
+		{id: 271, typ: 2, count: 7, value: ascii("nifuda")},     // Make
+		{id: 330, typ: 4, count: 2, ptrs: []*dir{raw, preview}}, // SubIFDs
+	}}
+	createFile("data/subifds.tif", build(bo, dir0, raw, preview, nested))
+
+	// BigTIFF, with an Exif IFD pointed using IFD8 type, strips beyond 4 GiB and a thumbnail recorded using LONG8 type
+	le := binary.LittleEndian
+	exif = &dir{entries: []entry{
+		{id: 36864, typ: 7, count: 4, value: []byte("0231")}, // ExifVersion
+	}}
+	dir1 = &dir{entries: []entry{
+		{id: 259, typ: 3, count: 1, value: short(le, 6)},                       // Compression
+		{id: 513, typ: 16, count: 1, blob: thumbnail},                          // JPEGInterchangeFormat
+		{id: 514, typ: 16, count: 1, value: long8(le, uint64(len(thumbnail)))}, // JPEGInterchangeFormatLength
+	}}
+	dir0 = &dir{entries: []entry{
+		{id: 256, typ: 16, count: 1, value: long8(le, 6048)},                          // ImageWidth
+		{id: 257, typ: 16, count: 1, value: long8(le, 4024)},                          // ImageLength
+		{id: 271, typ: 2, count: 7, value: ascii("nifuda")},                           // Make
+		{id: 273, typ: 16, count: 2, value: long8(le, 0x100000000, 0x100000000+4096)}, // StripOffsets
+		{id: 278, typ: 16, count: 1, value: long8(le, 2012)},                          // RowsPerStrip
+		{id: 279, typ: 16, count: 2, value: long8(le, 4096, 4096)},                    // StripByteCounts
+		{id: 315, typ: 2, count: 13, value: ascii("Pink Panther")},                    // Artist
+		{id: 34665, typ: 18, count: 1, ptrs: []*dir{exif}},                            // Exif IFD
+	}, next: dir1}
+	createFile("data/bigtiff.tif", buildBig(le, dir0, exif, dir1))
+
+	// Exif IFD with a tag whose value is out of the file, and GPS IFD out of the file
+	exif = &dir{entries: []entry{
+		{id: 36864, typ: 7, count: 4, value: []byte("0231")},  // ExifVersion
+		{id: 36867, typ: 2, count: 20, value: long(bo, 9999)}, // DateTimeOriginal
+		{id: 37520, typ: 2, count: 3, value: ascii("72")},     // SubSecTime
+	}}
+	dir0 = &dir{entries: []entry{
+		{id: 271, typ: 2, count: 7, value: ascii("nifuda")},  // Make
+		{id: 34665, typ: 4, count: 1, ptrs: []*dir{exif}},    // Exif IFD
+		{id: 34853, typ: 4, count: 1, value: long(bo, 9999)}, // GPS IFD
+	}}
+	createFile("data/wrong_offsets.tif", build(bo, dir0, exif))
+
+	// Private tags, and a BitsPerSample tag recorded with an unexpected type
+	dir0 = &dir{entries: []entry{
+		{id: 258, typ: 4, count: 1, value: long(bo, 8)},        // BitsPerSample
+		{id: 271, typ: 2, count: 7, value: ascii("nifuda")},    // Make
+		{id: 65000, typ: 2, count: 8, value: ascii("private")}, // private ASCII tag
+		{id: 65001, typ: 3, count: 1, value: short(bo, 2)},     // private enumerated tag
+	}}
+	createFile("data/private.tif", build(bo, dir0))
+
+	// Chain of 3 IFDs, laid out in the reverse order of the chain
+	dir2 := &dir{entries: []entry{
+		{id: 257, typ: 3, count: 1, value: short(bo, 2)}, // ImageLength
+	}}
+	dir1 = &dir{entries: []entry{
+		{id: 257, typ: 3, count: 1, value: short(bo, 1)}, // ImageLength
+	}, next: dir2}
+	dir0 = &dir{entries: []entry{
+		{id: 257, typ: 3, count: 1, value: short(bo, 0)}, // ImageLength
+	}, next: dir1}
+	createFile("data/chain.tif", build(bo, dir0, dir2, dir1))
+
+	// Canon MakerNote, an IFD whose offsets are counted from the TIFF header of the file
+	settings := make([]uint16, 25)
+	settings[0] = uint16(2 * len(settings))
+	settings[5] = 1 // ContinuousDrive, continuous
+	settings[7] = 1 // FocusMode, AI servo AF
+	canon := &dir{entries: []entry{
+		{id: 0x0001, typ: 3, count: 25, value: short(le, settings...)},          // CameraSettings
+		{id: 0x0004, typ: 3, count: 4, value: short(le, 8, 0, 160, 5)},          // ShotInfo
+		{id: 0x0006, typ: 2, count: 21, value: ascii("Canon EOS 5D Mark IV")},   // ImageType
+		{id: 0x0007, typ: 2, count: 15, value: ascii("Firmware 1.0.4")},         // FirmwareVersion
+		{id: 0x0008, typ: 4, count: 1, value: long(le, 1001234)},                // FileNumber
+		{id: 0x000c, typ: 4, count: 1, value: long(le, 12345678)},               // SerialNumber
+		{id: 0x0093, typ: 3, count: 3, value: short(le, 6, 0, 1)},               // FileInfo
+		{id: 0x0095, typ: 2, count: 23, value: ascii("EF24-105mm f/4L IS USM")}, // LensModel
+		{id: 0x0096, typ: 2, count: 10, value: ascii("XA1234567")},              // InternalSerialNumber
+	}}
+	exif = &dir{entries: []entry{
+		{id: 36864, typ: 7, count: 4, value: []byte("0231")}, // ExifVersion
+		{id: 37500, typ: 7, embed: canon},                    // MakerNote
+	}}
+	dir0 = &dir{entries: []entry{
+		{id: 271, typ: 2, count: 6, value: ascii("Canon")},                 // Make
+		{id: 272, typ: 2, count: 21, value: ascii("Canon EOS 5D Mark IV")}, // Model
+		{id: 34665, typ: 4, count: 1, ptrs: []*dir{exif}},                  // Exif IFD
+	}}
+	createFile("data/canon.tif", build(le, dir0, exif, canon))
+
 }
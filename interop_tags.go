@@ -0,0 +1,27 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+// InteropTags contains tags from Interoperability SubIFD.
+// Fields are defined in order they appeared in chapter 4.6.7 of Exif 2.31
+type InteropTags struct {
+	InteroperabilityIndex   string
+	InteroperabilityVersion string
+	RelatedImageFileFormat  string
+	RelatedImageWidth       uint32
+	RelatedImageLength      uint32
+}
+
+var interopTagDefs = []TagDef{
+	{ID: 1, IFD: "Interop", Name: "InteroperabilityIndex", Types: []uint16{TypeASCII},
+		Description: "Identification of the Interoperability rule"},
+	{ID: 2, IFD: "Interop", Name: "InteroperabilityVersion", Types: []uint16{TypeUndefined}, Count: 4,
+		Description: "Version of the Interoperability rule"},
+	{ID: 4096, IFD: "Interop", Name: "RelatedImageFileFormat", Types: []uint16{TypeASCII},
+		Description: "File format of the related image file"},
+	{ID: 4097, IFD: "Interop", Name: "RelatedImageWidth", Types: []uint16{TypeShort, TypeLong}, Count: 1,
+		Description: "Width of the related image"},
+	{ID: 4098, IFD: "Interop", Name: "RelatedImageLength", Types: []uint16{TypeShort, TypeLong}, Count: 1,
+		Description: "Height of the related image"},
+}
@@ -11,32 +11,36 @@ import (
 	"io"
 )
 
-// jpegRead parses JPEG from an io.ReadSeeker to retrieve embedded Tiff file hosting Exif tags.
+// jpegRead parses JPEG of size bytes from an io.ReaderAt to retrieve embedded Tiff file hosting Exif tags.
 // Returns an error if no Exif data found.
-func jpegRead(rs io.ReadSeeker) (*Exif, error) {
+func jpegRead(r io.ReaderAt, size int64, opts Options) (*Exif, error) {
+	rs := io.NewSectionReader(r, 0, size) // segments are read sequentially, using a cursor local to this call
+
 	// ensure we have a SOI
 	s0, err := nextSegment(rs)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read SOI: %w", err)
+		return nil, jpegError(0, fmt.Errorf("unable to read SOI: %w", err))
 	}
 	if s0.marker != mSOI {
-		return nil, errors.New("first segment must be SOI")
+		return nil, jpegError(0, errors.New("first segment must be SOI"))
 	}
 
 	// next segments until we have found APP1 Exif
+	offset := s0.size()
 	for {
 		s, err := nextSegment(rs)
 		if err != nil {
-			return nil, err
+			return nil, jpegError(offset, err)
 		}
+		offset += s.size()
 
-		if s.marker == mAPP1 && string(s.data[0:6]) == "Exif\x00\x00" {
-			x, err := tiffRead(bytes.NewReader(s.data[6:]))
+		if s.marker == mAPP1 && len(s.data) >= 6 && string(s.data[0:6]) == "Exif\x00\x00" {
+			x, err := tiffRead(bytes.NewReader(s.data[6:]), int64(len(s.data)-6), opts)
 			return x, err
 		}
 
 		if s.marker == mEOI || s.marker == mSOS { // don't know how to process after SOS marker
-			return nil, errors.New("no Exif data found")
+			return nil, ErrNoExifData
 		}
 	}
 }
@@ -48,6 +52,14 @@ type segment struct {
 	data   []byte // payload data
 }
 
+// size returns the number of bytes used by the segment in the file
+func (s *segment) size() uint64 {
+	if s.marker == mSOI || s.marker == mEOI {
+		return 2
+	}
+	return 2 + uint64(s.length)
+}
+
 // Restricted list to the used JPEG markers.
 // For full list see https://www.disktuna.com/list-of-jpeg-markers/
 const (
@@ -61,7 +73,7 @@ func nextSegment(r io.Reader) (*segment, error) {
 	buf := make([]byte, 2) // used to read marker & length
 
 	// marker
-	if _, err := r.Read(buf); err != nil {
+	if _, err := io.ReadFull(r, buf); err != nil {
 		return nil, fmt.Errorf("failed to read segment marker: %w", err)
 	}
 	if buf[0] != 0xff {
@@ -74,20 +86,18 @@ func nextSegment(r io.Reader) (*segment, error) {
 	}
 
 	// length
-	if _, err := r.Read(buf); err != nil {
+	if _, err := io.ReadFull(r, buf); err != nil {
 		return nil, fmt.Errorf("failed to read segment length: %w", err)
 	}
 	binary.Read(bytes.NewReader(buf), binary.BigEndian, &s.length)
+	if s.length < 2 { // length includes its own two bytes
+		return nil, fmt.Errorf("invalid segment length %d", s.length)
+	}
 
 	// data
 	s.data = make([]byte, s.length-2)
-	read := 0
-	for read < int(s.length)-2 {
-		n, err := r.Read(s.data[read:])
-		if err != nil {
-			return nil, fmt.Errorf("failed to read segment data: %w", err)
-		}
-		read += n
+	if _, err := io.ReadFull(r, s.data); err != nil {
+		return nil, fmt.Errorf("failed to read segment data: %w", err)
 	}
 
 	return s, nil
@@ -0,0 +1,100 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Catalog contains the translations of tag names and of descriptions of enumerated values for a language.
+//
+// Descriptions are keyed by their English text, as returned by the String method of enumerated types,
+// so that the same translation applies to every tag using the same description.
+type Catalog struct {
+	Names  map[string]string // translated tag names, keyed by tag name
+	Values map[string]string // translated descriptions, keyed by English description
+}
+
+// Catalogs for French and Japanese are built in.
+var catalogs = struct {
+	sync.RWMutex
+	byLang map[string]Catalog
+}{byLang: map[string]Catalog{
+	"fr": catalogFr,
+	"ja": catalogJa,
+}}
+
+// RegisterCatalog adds translations for a language, identified by a BCP 47 tag like "de" or "pt-BR".
+// Translations are merged with the ones already registered for the language, replacing them when they exist.
+func RegisterCatalog(lang string, c Catalog) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+
+	lang = strings.ToLower(lang)
+	current, ok := catalogs.byLang[lang]
+	if !ok {
+		current = Catalog{Names: make(map[string]string), Values: make(map[string]string)}
+		catalogs.byLang[lang] = current
+	}
+	for k, v := range c.Names {
+		current.Names[k] = v
+	}
+	for k, v := range c.Values {
+		current.Values[k] = v
+	}
+}
+
+// Printer renders tag names and values in a language.
+// Texts without translation are rendered in English.
+type Printer struct {
+	lang string
+}
+
+// NewPrinter returns a Printer for the language identified by a BCP 47 tag like "fr" or "ja-JP".
+// A regional variant without catalog uses the catalog of its base language.
+func NewPrinter(lang string) *Printer {
+	lang = strings.ToLower(lang)
+
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	if _, ok := catalogs.byLang[lang]; !ok {
+		if i := strings.IndexAny(lang, "-_"); i > 0 {
+			lang = lang[:i]
+		}
+	}
+	return &Printer{lang: lang}
+}
+
+// TagName returns the translated name of the tag with the given name, as used in Exif 2.31.
+func (p *Printer) TagName(name string) string {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+
+	if s, ok := catalogs.byLang[p.lang].Names[name]; ok {
+		return s
+	}
+	return name
+}
+
+// Sprint formats v like fmt.Sprint, translating the description of enumerated values.
+func (p *Printer) Sprint(v interface{}) string {
+	return p.translate(fmt.Sprint(v))
+}
+
+// Describe returns the value of tag as text, translating the description of enumerated values.
+func (p *Printer) Describe(def TagDef, tag Tag) string {
+	return p.translate(def.Describe(tag))
+}
+
+func (p *Printer) translate(s string) string {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+
+	if t, ok := catalogs.byLang[p.lang].Values[s]; ok {
+		return t
+	}
+	return s
+}
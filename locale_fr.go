@@ -0,0 +1,380 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+var catalogFr = Catalog{
+	Names: map[string]string{
+		// IFD0
+		"NewSubfileType":              "Type de sous-fichier",
+		"ImageWidth":                  "Largeur de l'image",
+		"ImageLength":                 "Hauteur de l'image",
+		"BitsPerSample":               "Bits par composante",
+		"Compression":                 "Compression",
+		"PhotometricInterpretation":   "Interprétation photométrique",
+		"Orientation":                 "Orientation",
+		"SamplesPerPixel":             "Composantes par pixel",
+		"PlanarConfiguration":         "Organisation des données",
+		"YCbCrSubSampling":            "Sous-échantillonnage YCbCr",
+		"YCbCrPositioning":            "Positionnement YCbCr",
+		"XResolution":                 "Résolution horizontale",
+		"YResolution":                 "Résolution verticale",
+		"ResolutionUnit":              "Unité de résolution",
+		"ExifIFDPointer":              "Pointeur IFD Exif",
+		"GPSInfoIFDPointer":           "Pointeur IFD GPS",
+		"InteroperabilityIFDPointer":  "Pointeur IFD d'interopérabilité",
+		"SubIFDs":                     "Sous-IFD",
+		"StripOffsets":                "Position des bandes",
+		"RowsPerStrip":                "Lignes par bande",
+		"StripByteCounts":             "Octets par bande",
+		"TileWidth":                   "Largeur des tuiles",
+		"TileLength":                  "Hauteur des tuiles",
+		"TileOffsets":                 "Position des tuiles",
+		"TileByteCounts":              "Octets par tuile",
+		"JPEGInterchangeFormat":       "Position de la vignette JPEG",
+		"JPEGInterchangeFormatLength": "Taille de la vignette JPEG",
+		"TransferFunction":            "Fonction de transfert",
+		"WhitePoint":                  "Point blanc",
+		"PrimaryChromaticities":       "Chromaticités des couleurs primaires",
+		"YCbCrCoefficients":           "Coefficients de la matrice de transformation YCbCr",
+		"ReferenceBlackWhite":         "Valeurs de référence du noir et du blanc",
+		"DateTime":                    "Date et heure de modification",
+		"ImageDescription":            "Description de l'image",
+		"Make":                        "Fabricant",
+		"Model":                       "Modèle",
+		"Software":                    "Logiciel",
+		"Artist":                      "Auteur",
+		"Copyright":                   "Copyright",
+		// Exif
+		"ExifVersion":               "Version Exif",
+		"FlashpixVersion":           "Version Flashpix",
+		"DateTimeOriginal":          "Date et heure de prise de vue",
+		"DateTimeDigitized":         "Date et heure de numérisation",
+		"OffsetTime":                "Décalage horaire de modification",
+		"OffsetTimeOriginal":        "Décalage horaire de prise de vue",
+		"OffsetTimeDigitized":       "Décalage horaire de numérisation",
+		"SubSecTime":                "Fraction de seconde de modification",
+		"SubSecTimeOriginal":        "Fraction de seconde de prise de vue",
+		"SubSecTimeDigitized":       "Fraction de seconde de numérisation",
+		"ColorSpace":                "Espace colorimétrique",
+		"Gamma":                     "Gamma",
+		"ComponentsConfiguration":   "Configuration des composantes",
+		"CompressedBitsPerPixel":    "Bits par pixel après compression",
+		"PixelXDimension":           "Largeur de l'image valide",
+		"PixelYDimension":           "Hauteur de l'image valide",
+		"MakerNote":                 "Note du fabricant",
+		"UserComment":               "Commentaire de l'utilisateur",
+		"RelatedSoundFile":          "Fichier audio associé",
+		"ExposureTime":              "Temps d'exposition",
+		"FNumber":                   "Nombre d'ouverture",
+		"ExposureProgram":           "Programme d'exposition",
+		"SpectralSensitivity":       "Sensibilité spectrale",
+		"PhotographicSensitivity":   "Sensibilité ISO",
+		"OECF":                      "Fonction de conversion opto-électronique",
+		"SensitivityType":           "Type de sensibilité",
+		"StandardOutputSensitivity": "Sensibilité de sortie standard",
+		"RecommendedExposureIndex":  "Indice d'exposition recommandé",
+		"ISOSpeed":                  "Vitesse ISO",
+		"ISOSpeedLatitudeyyy":       "Latitude de vitesse ISO yyy",
+		"ISOSpeedLatitudezzz":       "Latitude de vitesse ISO zzz",
+		"ShutterSpeedValue":         "Vitesse d'obturation",
+		"ApertureValue":             "Ouverture",
+		"BrightnessValue":           "Luminosité",
+		"ExposureBiasValue":         "Correction d'exposition",
+		"MaxApertureValue":          "Ouverture maximale",
+		"SubjectDistance":           "Distance du sujet",
+		"MeteringMode":              "Mode de mesure",
+		"LightSource":               "Source lumineuse",
+		"Flash":                     "Flash",
+		"FocalLength":               "Focale",
+		"SubjectArea":               "Zone du sujet",
+		"FlashEnergy":               "Énergie du flash",
+		"SpatialFrequencyResponse":  "Réponse en fréquence spatiale",
+		"FocalPlaneXResolution":     "Résolution horizontale du plan focal",
+		"FocalPlaneYResolution":     "Résolution verticale du plan focal",
+		"FocalPlaneResolutionUnit":  "Unité de résolution du plan focal",
+		"SubjectLocation":           "Position du sujet",
+		"ExposureIndex":             "Indice d'exposition",
+		"SensingMethod":             "Type de capteur",
+		"FileSource":                "Source du fichier",
+		"SceneType":                 "Type de scène",
+		"CFAPattern":                "Motif de la matrice de filtres colorés",
+		"CustomRendered":            "Traitement personnalisé",
+		"ExposureMode":              "Mode d'exposition",
+		"WhiteBalance":              "Balance des blancs",
+		"DigitalZoomRatio":          "Rapport de zoom numérique",
+		"FocalLengthIn35mmFilm":     "Focale équivalente en 35 mm",
+		"SceneCaptureType":          "Type de prise de vue",
+		"GainControl":               "Contrôle du gain",
+		"Contrast":                  "Contraste",
+		"Saturation":                "Saturation",
+		"Sharpness":                 "Netteté",
+		"DeviceSettingDescription":  "Réglages de l'appareil",
+		"SubjectDistanceRange":      "Plage de distance du sujet",
+		"ImageUniqueID":             "Identifiant unique de l'image",
+		"CameraOwnerName":           "Propriétaire de l'appareil",
+		"BodySerialNumber":          "Numéro de série du boîtier",
+		"LensSpecification":         "Caractéristiques de l'objectif",
+		"LensMake":                  "Fabricant de l'objectif",
+		"LensModel":                 "Modèle de l'objectif",
+		"LensSerialNumber":          "Numéro de série de l'objectif",
+		// GPS
+		"GPSVersionID":         "Version GPS",
+		"GPSLatitudeRef":       "Hémisphère de la latitude",
+		"GPSLatitude":          "Latitude",
+		"GPSLongitudeRef":      "Hémisphère de la longitude",
+		"GPSLongitude":         "Longitude",
+		"GPSAltitudeRef":       "Référence d'altitude",
+		"GPSAltitude":          "Altitude",
+		"GPSTimeStamp":         "Heure GPS (UTC)",
+		"GPSSatellites":        "Satellites",
+		"GPSStatus":            "État du récepteur",
+		"GPSMeasureMode":       "Mode de mesure GPS",
+		"GPSDOP":               "Précision de la mesure",
+		"GPSSpeedRef":          "Unité de vitesse",
+		"GPSSpeed":             "Vitesse",
+		"GPSTrackRef":          "Référence de la direction de déplacement",
+		"GPSTrack":             "Direction de déplacement",
+		"GPSImgDirectionRef":   "Référence de la direction de l'image",
+		"GPSImgDirection":      "Direction de l'image",
+		"GPSMapDatum":          "Système géodésique",
+		"GPSDestLatitudeRef":   "Hémisphère de la latitude de destination",
+		"GPSDestLatitude":      "Latitude de destination",
+		"GPSDestLongitudeRef":  "Hémisphère de la longitude de destination",
+		"GPSDestLongitude":     "Longitude de destination",
+		"GPSDestBearingRef":    "Référence du cap de destination",
+		"GPSDestBearing":       "Cap de destination",
+		"GPSDestDistanceRef":   "Unité de distance de destination",
+		"GPSDestDistance":      "Distance de destination",
+		"GPSProcessingMethod":  "Méthode de localisation",
+		"GPSAreaInformation":   "Nom de la zone GPS",
+		"GPSDateStamp":         "Date GPS (UTC)",
+		"GPSDifferential":      "Correction différentielle",
+		"GPSHPositioningError": "Erreur de positionnement horizontal",
+		// Interop
+		"InteroperabilityIndex":   "Index d'interopérabilité",
+		"InteroperabilityVersion": "Version d'interopérabilité",
+		"RelatedImageFileFormat":  "Format du fichier de l'image associée",
+		"RelatedImageWidth":       "Largeur de l'image associée",
+		"RelatedImageLength":      "Hauteur de l'image associée",
+		// Nikon
+		"MakerNoteVersion": "Version de la note du fabricant",
+		"Quality":          "Qualité d'image",
+		"FocusMode":        "Mode de mise au point",
+		"SerialNumber":     "Numéro de série de l'appareil",
+		"VRInfo":           "Réduction de vibration",
+		"ActiveDLighting":  "D-Lighting actif",
+		"LensType":         "Type d'objectif",
+		"Lens":             "Objectif",
+		"ShotInfo":         "Informations de prise de vue",
+		"LensData":         "Données de l'objectif",
+		"ShutterCount":     "Nombre de déclenchements",
+		// Canon
+		"CameraSettings":       "Réglages de l'appareil",
+		"ImageType":            "Type d'image",
+		"FirmwareVersion":      "Version du micrologiciel",
+		"FileNumber":           "Numéro de fichier",
+		"OwnerName":            "Nom du propriétaire",
+		"ModelID":              "Identifiant du modèle",
+		"FileInfo":             "Informations sur le fichier",
+		"InternalSerialNumber": "Numéro de série interne",
+		"DriveMode":            "Mode d'entraînement",
+	},
+	Values: map[string]string{
+		// Compression
+		"uncompressed":         "non compressé",
+		"JPEG compression":     "compression JPEG",
+		"Nikon NEF Compressed": "NEF Nikon compressé",
+		// Orientation
+		"horizontal (normal)":                 "horizontale (normale)",
+		"mirror horizontal":                   "miroir horizontal",
+		"rotate 180":                          "rotation de 180°",
+		"mirror vertical":                     "miroir vertical",
+		"mirror horizontal and rotate 270 CW": "miroir horizontal et rotation de 270° horaire",
+		"rotate 90 CW":                        "rotation de 90° horaire",
+		"mirror horizontal and rotate 90 CW":  "miroir horizontal et rotation de 90° horaire",
+		"rotate 270 CW":                       "rotation de 270° horaire",
+		// PhotometricInterpretation
+		"WhiteIsZero": "blanc à zéro",
+		"BlackIsZero": "noir à zéro",
+		// PlanarConfiguration
+		"chunky format": "format entrelacé",
+		"planar format": "format planaire",
+		// YCbCrPositioning
+		"centered": "centré",
+		"co-sited": "co-situé",
+		// ResolutionUnit
+		"inches":      "pouces",
+		"centimeters": "centimètres",
+		// ExposureProgram
+		"not defined":       "non défini",
+		"manual":            "manuel",
+		"normal program":    "programme normal",
+		"aperture priority": "priorité à l'ouverture",
+		"shutter priority":  "priorité à la vitesse",
+		"creative program":  "programme créatif",
+		"action program":    "programme action",
+		"portrait mode":     "mode portrait",
+		"landscape mode":    "mode paysage",
+		// MeteringMode
+		"unknown":                 "inconnu",
+		"average":                 "moyenne",
+		"center-weighted average": "moyenne pondérée centrale",
+		"spot":                    "spot",
+		"multispot":               "multi-spot",
+		"pattern":                 "matricielle",
+		"partial":                 "partielle",
+		"other":                   "autre",
+		// ColorSpace
+		"uncalibrated": "non calibré",
+		// SensitivityType
+		"standard output sensitivity": "sensibilité de sortie standard",
+		"recommended exposure index":  "indice d'exposition recommandé",
+		"ISO speed":                   "vitesse ISO",
+		"standard output sensitivity and recommended exposure index":            "sensibilité de sortie standard et indice d'exposition recommandé",
+		"standard output sensitivity and ISO speed":                             "sensibilité de sortie standard et vitesse ISO",
+		"recommended exposure index and ISO speed":                              "indice d'exposition recommandé et vitesse ISO",
+		"standard output sensitivity, recommended exposure index and ISO speed": "sensibilité de sortie standard, indice d'exposition recommandé et vitesse ISO",
+		// LightSource
+		"daylight":                               "lumière du jour",
+		"fluorescent":                            "fluorescent",
+		"tungsten (incandescent light)":          "tungstène (lumière incandescente)",
+		"flash":                                  "flash",
+		"fine weather":                           "beau temps",
+		"cloudy weather":                         "temps nuageux",
+		"shade":                                  "ombre",
+		"daylight fluorescent (D 5700 - 7100K)":  "fluorescent lumière du jour (D 5700 - 7100K)",
+		"day white fluorescent (N 4600 - 5500K)": "fluorescent blanc jour (N 4600 - 5500K)",
+		"cool white fluorescent (W 3800 - 4500K)": "fluorescent blanc froid (W 3800 - 4500K)",
+		"white fluorescent (WW 3250 - 3800K)":     "fluorescent blanc (WW 3250 - 3800K)",
+		"warm white fluorescent (L 2600 - 3250K)": "fluorescent blanc chaud (L 2600 - 3250K)",
+		"standard light A":                        "lumière standard A",
+		"standard light B":                        "lumière standard B",
+		"standard light C":                        "lumière standard C",
+		"ISO studio tungsten":                     "tungstène de studio ISO",
+		"other light source":                      "autre source lumineuse",
+		// Flash
+		"flash did not fire":                                                                    "flash non déclenché",
+		"flash fired":                                                                           "flash déclenché",
+		"flash fired, return light not detected":                                                "flash déclenché, retour de lumière non détecté",
+		"flash fired, return light detected":                                                    "flash déclenché, retour de lumière détecté",
+		"flash fired, compulsory flash mode":                                                    "flash déclenché, mode forcé",
+		"flash fired, compulsory flash mode, return light not detected":                         "flash déclenché, mode forcé, retour de lumière non détecté",
+		"flash fired, compulsory flash mode, return light detected":                             "flash déclenché, mode forcé, retour de lumière détecté",
+		"flash did not fire, compulsory flash mode":                                             "flash non déclenché, mode forcé",
+		"flash did not fire, return light not detected":                                         "flash non déclenché, retour de lumière non détecté",
+		"flash did not fire, auto mode":                                                         "flash non déclenché, mode automatique",
+		"flash fired, auto mode":                                                                "flash déclenché, mode automatique",
+		"flash fired, auto mode, return light not detected":                                     "flash déclenché, mode automatique, retour de lumière non détecté",
+		"flash fired, auto mode, return light detected":                                         "flash déclenché, mode automatique, retour de lumière détecté",
+		"no flash function":                                                                     "pas de flash",
+		"flash did not fire, no flash function":                                                 "flash non déclenché, pas de flash",
+		"flash fired, red-eye reduction mode":                                                   "flash déclenché, réduction des yeux rouges",
+		"flash fired, red-eye reduction mode, return light not detected":                        "flash déclenché, réduction des yeux rouges, retour de lumière non détecté",
+		"flash fired, red-eye reduction mode, return light detected":                            "flash déclenché, réduction des yeux rouges, retour de lumière détecté",
+		"flash fired, compulsory flash mode, red-eye reduction mode":                            "flash déclenché, mode forcé, réduction des yeux rouges",
+		"flash fired, compulsory flash mode, red-eye reduction mode, return light not detected": "flash déclenché, mode forcé, réduction des yeux rouges, retour de lumière non détecté",
+		"flash fired, compulsory flash mode, red-eye reduction mode, return light detected":     "flash déclenché, mode forcé, réduction des yeux rouges, retour de lumière détecté",
+		"flash did not fire, red-eye reduction mode":                                            "flash non déclenché, réduction des yeux rouges",
+		"flash did not fire, auto mode, red-eye reduction mode":                                 "flash non déclenché, mode automatique, réduction des yeux rouges",
+		"flash fired, auto mode, red-eye reduction mode":                                        "flash déclenché, mode automatique, réduction des yeux rouges",
+		"flash fired, auto mode, return light not detected, red-eye reduction mode":             "flash déclenché, mode automatique, retour de lumière non détecté, réduction des yeux rouges",
+		"flash fired, auto mode, return light detected, red-eye reduction mode":                 "flash déclenché, mode automatique, retour de lumière détecté, réduction des yeux rouges",
+		// SensingMethod
+		"one-chip color area sensor":     "capteur de zone couleur à une puce",
+		"two-chip color area sensor":     "capteur de zone couleur à deux puces",
+		"three-chip color area sensor":   "capteur de zone couleur à trois puces",
+		"color sequential area sensor":   "capteur de zone couleur séquentiel",
+		"trilinear sensor":               "capteur trilinéaire",
+		"color sequential linear sensor": "capteur linéaire couleur séquentiel",
+		// FileSource and SceneType
+		"others":                      "autres",
+		"scanner of transparent type": "scanner de film",
+		"scanner of reflex type":      "scanner à plat",
+		"DSC":                         "appareil photo numérique",
+		"directly photographed image": "image photographiée directement",
+		// CustomRendered, ExposureMode and WhiteBalance
+		"normal process":       "traitement normal",
+		"custom process":       "traitement personnalisé",
+		"auto exposure":        "exposition automatique",
+		"manual exposure":      "exposition manuelle",
+		"auto bracket":         "bracketing automatique",
+		"auto white balance":   "balance des blancs automatique",
+		"manual white balance": "balance des blancs manuelle",
+		// SceneCaptureType
+		"standard":    "standard",
+		"landscape":   "paysage",
+		"portrait":    "portrait",
+		"night scene": "scène de nuit",
+		// GainControl
+		"none":           "aucun",
+		"low gain up":    "faible augmentation du gain",
+		"high gain up":   "forte augmentation du gain",
+		"low gain down":  "faible diminution du gain",
+		"high gain down": "forte diminution du gain",
+		// Contrast, Saturation and Sharpness
+		"normal":          "normal",
+		"soft":            "doux",
+		"hard":            "dur",
+		"low saturation":  "saturation faible",
+		"high saturation": "saturation élevée",
+		// SubjectDistanceRange
+		"macro":        "macro",
+		"close view":   "vue rapprochée",
+		"distant view": "vue éloignée",
+		// GPS
+		"North":                                "Nord",
+		"South":                                "Sud",
+		"East":                                 "Est",
+		"West":                                 "Ouest",
+		"Sea level":                            "Au-dessus du niveau de la mer",
+		"Sea level reference (negative value)": "Sous le niveau de la mer",
+		"Measurement in progress":              "Mesure en cours",
+		"Measurement interrupted":              "Mesure interrompue",
+		"2-dimensional measurement":            "Mesure en 2 dimensions",
+		"3-dimensional measurement":            "Mesure en 3 dimensions",
+		"Kilometers per hour":                  "Kilomètres par heure",
+		"Miles per hour":                       "Miles par heure",
+		"Knots":                                "Nœuds",
+		"Magnetic direction":                   "Nord magnétique",
+		"True direction":                       "Nord géographique",
+		"Kilometers":                           "Kilomètres",
+		"Miles":                                "Miles",
+		"Nautical miles":                       "Milles nautiques",
+		// ActiveDLighting
+		"off":          "désactivé",
+		"low":          "faible",
+		"high":         "élevé",
+		"extra high":   "très élevé",
+		"extra high 1": "très élevé 1",
+		"extra high 2": "très élevé 2",
+		"extra high 3": "très élevé 3",
+		"extra high 4": "très élevé 4",
+		"auto":         "automatique",
+		// VibrationReduction
+		"on":            "activé",
+		"not available": "non disponible",
+		// CanonDriveMode
+		"single":                     "vue par vue",
+		"continuous":                 "continu",
+		"movie":                      "vidéo",
+		"continuous, speed priority": "continu, priorité vitesse",
+		"continuous, low":            "continu, lent",
+		"continuous, high":           "continu, rapide",
+		"silent single":              "vue par vue silencieux",
+		"continuous, high+":          "continu, très rapide",
+		"single, silent":             "vue par vue, silencieux",
+		"continuous, silent":         "continu, silencieux",
+		// CanonFocusMode
+		"one-shot AF":             "AF one-shot",
+		"AI servo AF":             "AF AI servo",
+		"AI focus AF":             "AF AI focus",
+		"manual focus":            "mise au point manuelle",
+		"pan focus":               "mise au point fixe",
+		"one-shot AF (live view)": "AF one-shot (visée écran)",
+		"AI servo AF (live view)": "AF AI servo (visée écran)",
+		"AI focus AF (live view)": "AF AI focus (visée écran)",
+		"movie snap focus":        "mise au point vidéo instantanée",
+		"movie servo AF":          "AF servo vidéo",
+	},
+}
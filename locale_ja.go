@@ -0,0 +1,381 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+// Tag names follow the Japanese edition of Exif 2.31
+var catalogJa = Catalog{
+	Names: map[string]string{
+		// IFD0
+		"NewSubfileType":              "サブファイルの種類",
+		"ImageWidth":                  "画像の幅",
+		"ImageLength":                 "画像の高さ",
+		"BitsPerSample":               "画像のビットの深さ",
+		"Compression":                 "圧縮の種類",
+		"PhotometricInterpretation":   "画素構成",
+		"Orientation":                 "画像方向",
+		"SamplesPerPixel":             "コンポーネント数",
+		"PlanarConfiguration":         "画像データの並び",
+		"YCbCrSubSampling":            "YCCの画素構成（Cの間引き率）",
+		"YCbCrPositioning":            "YCCの画素構成（YとCの位置）",
+		"XResolution":                 "画像の幅の解像度",
+		"YResolution":                 "画像の高さの解像度",
+		"ResolutionUnit":              "画像の幅と高さの解像度の単位",
+		"ExifIFDPointer":              "Exif IFDへのポインタ",
+		"GPSInfoIFDPointer":           "GPS情報IFDへのポインタ",
+		"InteroperabilityIFDPointer":  "互換性IFDへのポインタ",
+		"SubIFDs":                     "サブIFD",
+		"StripOffsets":                "画像データのロケーション",
+		"RowsPerStrip":                "ストリップ中のライン数",
+		"StripByteCounts":             "ストリップのデータ量",
+		"TileWidth":                   "タイルの幅",
+		"TileLength":                  "タイルの高さ",
+		"TileOffsets":                 "タイルのロケーション",
+		"TileByteCounts":              "タイルのデータ量",
+		"JPEGInterchangeFormat":       "JPEGのSOIへのオフセット",
+		"JPEGInterchangeFormatLength": "JPEGデータのバイト数",
+		"TransferFunction":            "再生階調カーブ特性",
+		"WhitePoint":                  "参照白色点の色度座標値",
+		"PrimaryChromaticities":       "原色の色度座標値",
+		"YCbCrCoefficients":           "色変換マトリクス係数",
+		"ReferenceBlackWhite":         "参照黒色点値と参照白色点値",
+		"DateTime":                    "ファイル変更日時",
+		"ImageDescription":            "画像タイトル",
+		"Make":                        "画像入力機器のメーカー名",
+		"Model":                       "画像入力機器のモデル名",
+		"Software":                    "使用ソフトウェア名",
+		"Artist":                      "作者名",
+		"Copyright":                   "撮影著作権者／編集著作権者",
+		// Exif
+		"ExifVersion":               "Exifバージョン",
+		"FlashpixVersion":           "対応フラッシュピックスバージョン",
+		"DateTimeOriginal":          "原画像データの生成日時",
+		"DateTimeDigitized":         "デジタルデータの作成日時",
+		"OffsetTime":                "ファイル変更日時のオフセットデータ",
+		"OffsetTimeOriginal":        "原画像データの生成日時のオフセットデータ",
+		"OffsetTimeDigitized":       "デジタルデータの作成日時のオフセットデータ",
+		"SubSecTime":                "ファイル変更日時のサブセック",
+		"SubSecTimeOriginal":        "原画像データの生成日時のサブセック",
+		"SubSecTimeDigitized":       "デジタルデータの作成日時のサブセック",
+		"ColorSpace":                "色空間情報",
+		"Gamma":                     "再生ガンマ",
+		"ComponentsConfiguration":   "各コンポーネントの意味",
+		"CompressedBitsPerPixel":    "画像圧縮モード",
+		"PixelXDimension":           "実効画像幅",
+		"PixelYDimension":           "実効画像高さ",
+		"MakerNote":                 "メーカノート",
+		"UserComment":               "ユーザコメント",
+		"RelatedSoundFile":          "関連音声ファイル",
+		"ExposureTime":              "露出時間",
+		"FNumber":                   "Fナンバー",
+		"ExposureProgram":           "露出プログラム",
+		"SpectralSensitivity":       "スペクトル感度",
+		"PhotographicSensitivity":   "撮影感度",
+		"OECF":                      "光電変換関数",
+		"SensitivityType":           "感度種別",
+		"StandardOutputSensitivity": "標準出力感度",
+		"RecommendedExposureIndex":  "推奨露光指数",
+		"ISOSpeed":                  "ISOスピード",
+		"ISOSpeedLatitudeyyy":       "ISOスピードラチチュードyyy",
+		"ISOSpeedLatitudezzz":       "ISOスピードラチチュードzzz",
+		"ShutterSpeedValue":         "シャッタースピード",
+		"ApertureValue":             "絞り値",
+		"BrightnessValue":           "輝度値",
+		"ExposureBiasValue":         "露光補正値",
+		"MaxApertureValue":          "レンズ最小Ｆ値",
+		"SubjectDistance":           "被写体距離",
+		"MeteringMode":              "測光方式",
+		"LightSource":               "光源",
+		"Flash":                     "フラッシュ",
+		"FocalLength":               "レンズ焦点距離",
+		"SubjectArea":               "被写体領域",
+		"FlashEnergy":               "フラッシュ強度",
+		"SpatialFrequencyResponse":  "空間周波数応答",
+		"FocalPlaneXResolution":     "焦点面の幅の解像度",
+		"FocalPlaneYResolution":     "焦点面の高さの解像度",
+		"FocalPlaneResolutionUnit":  "焦点面解像度単位",
+		"SubjectLocation":           "被写体位置",
+		"ExposureIndex":             "露出インデックス",
+		"SensingMethod":             "センサー方式",
+		"FileSource":                "ファイルソース",
+		"SceneType":                 "シーンタイプ",
+		"CFAPattern":                "CFAパターン",
+		"CustomRendered":            "個別画像処理",
+		"ExposureMode":              "露出モード",
+		"WhiteBalance":              "ホワイトバランス",
+		"DigitalZoomRatio":          "デジタルズーム倍率",
+		"FocalLengthIn35mmFilm":     "35mm換算レンズ焦点距離",
+		"SceneCaptureType":          "撮影シーンタイプ",
+		"GainControl":               "ゲイン制御",
+		"Contrast":                  "撮影コントラスト",
+		"Saturation":                "撮影彩度",
+		"Sharpness":                 "撮影シャープネス",
+		"DeviceSettingDescription":  "撮影条件記述情報",
+		"SubjectDistanceRange":      "被写体距離レンジ",
+		"ImageUniqueID":             "画像ユニークID",
+		"CameraOwnerName":           "カメラ所有者名",
+		"BodySerialNumber":          "カメラシリアル番号",
+		"LensSpecification":         "レンズの仕様情報",
+		"LensMake":                  "レンズのメーカ名",
+		"LensModel":                 "レンズのモデル名",
+		"LensSerialNumber":          "レンズシリアル番号",
+		// GPS
+		"GPSVersionID":         "GPSタグのバージョン",
+		"GPSLatitudeRef":       "北緯(N) or 南緯(S)",
+		"GPSLatitude":          "緯度（度、分、秒）",
+		"GPSLongitudeRef":      "東経(E) or 西経(W)",
+		"GPSLongitude":         "経度（度、分、秒）",
+		"GPSAltitudeRef":       "高度の基準",
+		"GPSAltitude":          "高度（m）",
+		"GPSTimeStamp":         "GPSの時間（原子時計の時間）",
+		"GPSSatellites":        "測位に使用したGPS衛星",
+		"GPSStatus":            "GPS受信機の状態",
+		"GPSMeasureMode":       "GPSの測位方法",
+		"GPSDOP":               "測位の信頼性",
+		"GPSSpeedRef":          "速度の単位",
+		"GPSSpeed":             "速度",
+		"GPSTrackRef":          "進行方向の基準",
+		"GPSTrack":             "進行方向",
+		"GPSImgDirectionRef":   "撮影した画像の方向の基準",
+		"GPSImgDirection":      "撮影した画像の方向",
+		"GPSMapDatum":          "測位に用いた地図データ",
+		"GPSDestLatitudeRef":   "目的地の北緯(N) or 南緯(S)",
+		"GPSDestLatitude":      "目的地の緯度（度、分、秒）",
+		"GPSDestLongitudeRef":  "目的地の東経(E) or 西経(W)",
+		"GPSDestLongitude":     "目的地の経度（度、分、秒）",
+		"GPSDestBearingRef":    "目的地の方角の基準",
+		"GPSDestBearing":       "目的地の方角",
+		"GPSDestDistanceRef":   "目的地への距離の単位",
+		"GPSDestDistance":      "目的地への距離",
+		"GPSProcessingMethod":  "測位方式の名称",
+		"GPSAreaInformation":   "測位地点の名称",
+		"GPSDateStamp":         "GPS日付",
+		"GPSDifferential":      "GPS補正測位",
+		"GPSHPositioningError": "水平方向測位誤差",
+		// Interop
+		"InteroperabilityIndex":   "互換性インデックス",
+		"InteroperabilityVersion": "互換性バージョン",
+		"RelatedImageFileFormat":  "関連画像ファイルフォーマット",
+		"RelatedImageWidth":       "関連画像の幅",
+		"RelatedImageLength":      "関連画像の高さ",
+		// Nikon
+		"MakerNoteVersion": "メーカノートのバージョン",
+		"Quality":          "画質モード",
+		"FocusMode":        "フォーカスモード",
+		"SerialNumber":     "カメラのシリアル番号",
+		"VRInfo":           "手ぶれ補正",
+		"ActiveDLighting":  "アクティブD-ライティング",
+		"LensType":         "レンズの種類",
+		"Lens":             "レンズ",
+		"ShotInfo":         "撮影情報",
+		"LensData":         "レンズ情報",
+		"ShutterCount":     "シャッター回数",
+		// Canon
+		"CameraSettings":       "カメラ設定",
+		"ImageType":            "画像の種類",
+		"FirmwareVersion":      "ファームウェアのバージョン",
+		"FileNumber":           "ファイル番号",
+		"OwnerName":            "所有者名",
+		"ModelID":              "機種ID",
+		"FileInfo":             "ファイル情報",
+		"InternalSerialNumber": "内部シリアル番号",
+		"DriveMode":            "ドライブモード",
+	},
+	Values: map[string]string{
+		// Compression
+		"uncompressed":         "非圧縮",
+		"JPEG compression":     "JPEG圧縮",
+		"Nikon NEF Compressed": "ニコンNEF圧縮",
+		// Orientation
+		"horizontal (normal)":                 "標準",
+		"mirror horizontal":                   "左右反転",
+		"rotate 180":                          "180度回転",
+		"mirror vertical":                     "上下反転",
+		"mirror horizontal and rotate 270 CW": "左右反転して時計回りに270度回転",
+		"rotate 90 CW":                        "時計回りに90度回転",
+		"mirror horizontal and rotate 90 CW":  "左右反転して時計回りに90度回転",
+		"rotate 270 CW":                       "時計回りに270度回転",
+		// PhotometricInterpretation
+		"WhiteIsZero": "白がゼロ",
+		"BlackIsZero": "黒がゼロ",
+		// PlanarConfiguration
+		"chunky format": "点順次フォーマット",
+		"planar format": "面順次フォーマット",
+		// YCbCrPositioning
+		"centered": "中心",
+		"co-sited": "一致",
+		// ResolutionUnit
+		"inches":      "インチ",
+		"centimeters": "センチメートル",
+		// ExposureProgram
+		"not defined":       "未定義",
+		"manual":            "マニュアル",
+		"normal program":    "ノーマルプログラム",
+		"aperture priority": "絞り優先",
+		"shutter priority":  "シャッター優先",
+		"creative program":  "クリエイティブプログラム（被写界深度方向にバイアス）",
+		"action program":    "アクションプログラム（シャッタースピード高速側にバイアス）",
+		"portrait mode":     "ポートレイトモード（近景撮影用、背景はフォーカス外す）",
+		"landscape mode":    "ランドスケープモード（風景撮影用、背景はフォーカス合わせる）",
+		// MeteringMode
+		"unknown":                 "不明",
+		"average":                 "平均",
+		"center-weighted average": "中央重点",
+		"spot":                    "スポット",
+		"multispot":               "マルチスポット",
+		"pattern":                 "分割測光",
+		"partial":                 "部分測光",
+		"other":                   "その他",
+		// ColorSpace
+		"uncalibrated": "キャリブレーションなし",
+		// SensitivityType
+		"standard output sensitivity": "標準出力感度",
+		"recommended exposure index":  "推奨露光指数",
+		"ISO speed":                   "ISOスピード",
+		"standard output sensitivity and recommended exposure index":            "標準出力感度および推奨露光指数",
+		"standard output sensitivity and ISO speed":                             "標準出力感度およびISOスピード",
+		"recommended exposure index and ISO speed":                              "推奨露光指数およびISOスピード",
+		"standard output sensitivity, recommended exposure index and ISO speed": "標準出力感度、推奨露光指数およびISOスピード",
+		// LightSource
+		"daylight":                               "昼光",
+		"fluorescent":                            "蛍光灯",
+		"tungsten (incandescent light)":          "タングステン（白熱灯）",
+		"flash":                                  "フラッシュ",
+		"fine weather":                           "晴天",
+		"cloudy weather":                         "曇天",
+		"shade":                                  "日陰",
+		"daylight fluorescent (D 5700 - 7100K)":  "昼光色蛍光灯（D 5700 - 7100K）",
+		"day white fluorescent (N 4600 - 5500K)": "昼白色蛍光灯（N 4600 - 5500K）",
+		"cool white fluorescent (W 3800 - 4500K)": "白色蛍光灯（W 3800 - 4500K）",
+		"white fluorescent (WW 3250 - 3800K)":     "温白色蛍光灯（WW 3250 - 3800K）",
+		"warm white fluorescent (L 2600 - 3250K)": "電球色蛍光灯（L 2600 - 3250K）",
+		"standard light A":                        "標準光A",
+		"standard light B":                        "標準光B",
+		"standard light C":                        "標準光C",
+		"ISO studio tungsten":                     "ISOスタジオタングステン",
+		"other light source":                      "その他の光源",
+		// Flash
+		"flash did not fire":                                                                    "ストロボ発光せず",
+		"flash fired":                                                                           "ストロボ発光",
+		"flash fired, return light not detected":                                                "ストロボ発光、リターン検出せず",
+		"flash fired, return light detected":                                                    "ストロボ発光、リターン検出",
+		"flash fired, compulsory flash mode":                                                    "ストロボ発光、強制発光モード",
+		"flash fired, compulsory flash mode, return light not detected":                         "ストロボ発光、強制発光モード、リターン検出せず",
+		"flash fired, compulsory flash mode, return light detected":                             "ストロボ発光、強制発光モード、リターン検出",
+		"flash did not fire, compulsory flash mode":                                             "ストロボ発光せず、強制発光モード",
+		"flash did not fire, return light not detected":                                         "ストロボ発光せず、リターン検出せず",
+		"flash did not fire, auto mode":                                                         "ストロボ発光せず、オートモード",
+		"flash fired, auto mode":                                                                "ストロボ発光、オートモード",
+		"flash fired, auto mode, return light not detected":                                     "ストロボ発光、オートモード、リターン検出せず",
+		"flash fired, auto mode, return light detected":                                         "ストロボ発光、オートモード、リターン検出",
+		"no flash function":                                                                     "ストロボ機能なし",
+		"flash did not fire, no flash function":                                                 "ストロボ発光せず、ストロボ機能なし",
+		"flash fired, red-eye reduction mode":                                                   "ストロボ発光、赤目軽減モード",
+		"flash fired, red-eye reduction mode, return light not detected":                        "ストロボ発光、赤目軽減モード、リターン検出せず",
+		"flash fired, red-eye reduction mode, return light detected":                            "ストロボ発光、赤目軽減モード、リターン検出",
+		"flash fired, compulsory flash mode, red-eye reduction mode":                            "ストロボ発光、強制発光モード、赤目軽減モード",
+		"flash fired, compulsory flash mode, red-eye reduction mode, return light not detected": "ストロボ発光、強制発光モード、赤目軽減モード、リターン検出せず",
+		"flash fired, compulsory flash mode, red-eye reduction mode, return light detected":     "ストロボ発光、強制発光モード、赤目軽減モード、リターン検出",
+		"flash did not fire, red-eye reduction mode":                                            "ストロボ発光せず、赤目軽減モード",
+		"flash did not fire, auto mode, red-eye reduction mode":                                 "ストロボ発光せず、オートモード、赤目軽減モード",
+		"flash fired, auto mode, red-eye reduction mode":                                        "ストロボ発光、オートモード、赤目軽減モード",
+		"flash fired, auto mode, return light not detected, red-eye reduction mode":             "ストロボ発光、オートモード、リターン検出せず、赤目軽減モード",
+		"flash fired, auto mode, return light detected, red-eye reduction mode":                 "ストロボ発光、オートモード、リターン検出、赤目軽減モード",
+		// SensingMethod
+		"one-chip color area sensor":     "単板式カラーセンサー",
+		"two-chip color area sensor":     "2板式カラーセンサー",
+		"three-chip color area sensor":   "3板式カラーセンサー",
+		"color sequential area sensor":   "色順次カラーセンサー",
+		"trilinear sensor":               "3線リニアセンサー",
+		"color sequential linear sensor": "色順次リニアセンサー",
+		// FileSource and SceneType
+		"others":                      "その他",
+		"scanner of transparent type": "透過型スキャナ",
+		"scanner of reflex type":      "反射型スキャナ",
+		"DSC":                         "デジタルスチルカメラ",
+		"directly photographed image": "直接撮影された画像",
+		// CustomRendered, ExposureMode and WhiteBalance
+		"normal process":       "通常処理",
+		"custom process":       "特殊処理",
+		"auto exposure":        "露出自動",
+		"manual exposure":      "露出マニュアル",
+		"auto bracket":         "オートブラケット",
+		"auto white balance":   "ホワイトバランス自動",
+		"manual white balance": "ホワイトバランスマニュアル",
+		// SceneCaptureType
+		"standard":    "標準",
+		"landscape":   "風景",
+		"portrait":    "人物",
+		"night scene": "夜景",
+		// GainControl
+		"none":           "なし",
+		"low gain up":    "弱増感",
+		"high gain up":   "強増感",
+		"low gain down":  "弱減感",
+		"high gain down": "強減感",
+		// Contrast, Saturation and Sharpness
+		"normal":          "標準",
+		"soft":            "軟調",
+		"hard":            "硬調",
+		"low saturation":  "低彩度",
+		"high saturation": "高彩度",
+		// SubjectDistanceRange
+		"macro":        "マクロ",
+		"close view":   "近景",
+		"distant view": "遠景",
+		// GPS
+		"North":                                "北緯",
+		"South":                                "南緯",
+		"East":                                 "東経",
+		"West":                                 "西経",
+		"Sea level":                            "海抜",
+		"Sea level reference (negative value)": "海抜基準（負の値）",
+		"Measurement in progress":              "測位中",
+		"Measurement interrupted":              "測位中断中",
+		"2-dimensional measurement":            "2次元測位中",
+		"3-dimensional measurement":            "3次元測位中",
+		"Kilometers per hour":                  "キロメートル／時",
+		"Miles per hour":                       "マイル／時",
+		"Knots":                                "ノット",
+		"Magnetic direction":                   "磁気方位",
+		"True direction":                       "真方位",
+		"Kilometers":                           "キロメートル",
+		"Miles":                                "マイル",
+		"Nautical miles":                       "海里",
+		// ActiveDLighting
+		"off":          "しない",
+		"low":          "弱め",
+		"high":         "強め",
+		"extra high":   "より強め",
+		"extra high 1": "より強め1",
+		"extra high 2": "より強め2",
+		"extra high 3": "より強め3",
+		"extra high 4": "より強め4",
+		"auto":         "オート",
+		// VibrationReduction
+		"on":            "する",
+		"not available": "なし",
+		// CanonDriveMode
+		"single":                     "1枚撮影",
+		"continuous":                 "連続撮影",
+		"movie":                      "動画",
+		"continuous, speed priority": "連続撮影（速度優先）",
+		"continuous, low":            "低速連続撮影",
+		"continuous, high":           "高速連続撮影",
+		"silent single":              "静音1枚撮影",
+		"continuous, high+":          "超高速連続撮影",
+		"single, silent":             "1枚撮影（静音）",
+		"continuous, silent":         "連続撮影（静音）",
+		// CanonFocusMode
+		"one-shot AF":             "ワンショットAF",
+		"AI servo AF":             "AIサーボAF",
+		"AI focus AF":             "AIフォーカスAF",
+		"manual focus":            "マニュアルフォーカス",
+		"pan focus":               "パンフォーカス",
+		"one-shot AF (live view)": "ワンショットAF（ライブビュー）",
+		"AI servo AF (live view)": "AIサーボAF（ライブビュー）",
+		"AI focus AF (live view)": "AIフォーカスAF（ライブビュー）",
+		"movie snap focus":        "動画スナップフォーカス",
+		"movie servo AF":          "動画サーボAF",
+	},
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"bytes"
+	"strings"
+)
+
+// readMakerNote decodes the MakerNote tag of the Exif IFD when its format is known,
+// identified by its signature or by the Make of the camera for MakerNotes without signature.
+// MakerNotes in other formats remain available as raw data in PhotoTags.MakerNote.
+//
+// MakerNotes are decoded best-effort: their problems are recorded as warnings, even in strict mode,
+// so that they never prevent the decoding of the other tags. A MakerNote which can not be read
+// leaves the matching field of Exif, like Exif.Nikon, nil.
+func (f *tiffFile) readMakerNote(x *Exif, exifIFD *IFD) {
+	tag, ok := exifIFD.Tag(37500) // MakerNote
+	if !ok {
+		return
+	}
+
+	switch {
+	case bytes.HasPrefix(tag.Data, nikonSignature):
+		f.readNikonMakerNote(x, tag)
+	case strings.HasPrefix(x.Image.Make, "Canon"):
+		f.readCanonMakerNote(x, tag)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import "errors"
+
+// CanonTags contains tags from the MakerNote of Canon cameras.
+//
+// The MakerNote of Canon cameras is an IFD without signature nor header, whose offsets are counted from
+// the start of the TIFF header of the file, like the offsets of the other IFDs.
+//
+// CameraSettings, ShotInfo and FileInfo are arrays of values whose meaning depends of their index, starting
+// with the size of the array in bytes. They are indexed as in the Canon tags documentation of ExifTool.
+type CanonTags struct {
+	CameraSettings       []uint16
+	ShotInfo             []uint16
+	ImageType            string
+	FirmwareVersion      string
+	FileNumber           uint32
+	OwnerName            string
+	SerialNumber         uint32
+	ModelID              uint32
+	FileInfo             []uint16
+	LensModel            string // name of the lens, like "EF24-105mm f/4L IS USM"
+	InternalSerialNumber string
+
+	// Values decoded from CameraSettings
+	DriveMode CanonDriveMode
+	FocusMode CanonFocusMode
+}
+
+// CanonDriveMode is the drive mode, recorded at index 5 of CameraSettings.
+// As 0 is a valid value, DriveMode is only meaningful if CameraSettings is recorded.
+type CanonDriveMode uint16
+
+func (v CanonDriveMode) String() string {
+	return describeValue("CanonCameraSettings", 5, "CanonDriveMode", uint16(v))
+}
+
+// CanonFocusMode is the focus mode, recorded at index 7 of CameraSettings.
+// As 0 is a valid value, FocusMode is only meaningful if CameraSettings is recorded.
+type CanonFocusMode uint16
+
+func (v CanonFocusMode) String() string {
+	return describeValue("CanonCameraSettings", 7, "CanonFocusMode", uint16(v))
+}
+
+// readCanonMakerNote decodes a Canon MakerNote and adds its IFD to x.IFDs as "Canon".
+func (f *tiffFile) readCanonMakerNote(x *Exif, tag Tag) {
+	if tag.offset == 0 {
+		f.addWarning(tiffError("Exif", tag.ID, 0, errors.New("MakerNote too short for a Canon IFD")))
+		return
+	}
+	ifd, err := f.readIFD("Canon", tag.offset)
+	if err != nil {
+		f.addWarning(err)
+		return
+	}
+	x.IFDs[ifd.Name] = ifd
+
+	t := &CanonTags{}
+	f.decodeTags(ifd, t)
+	if len(t.CameraSettings) > 7 {
+		t.DriveMode = CanonDriveMode(t.CameraSettings[5])
+		t.FocusMode = CanonFocusMode(t.CameraSettings[7])
+	}
+	x.Canon = t
+}
+
+var canonTagDefs = []TagDef{
+	{ID: 0x0001, IFD: "Canon", Name: "CameraSettings", Types: []uint16{TypeShort},
+		Description: "Camera settings, like the drive mode and the focus mode"},
+	{ID: 0x0004, IFD: "Canon", Name: "ShotInfo", Types: []uint16{TypeShort},
+		Description: "Shooting information, like the exposure and the white balance"},
+	{ID: 0x0006, IFD: "Canon", Name: "ImageType", Types: []uint16{TypeASCII},
+		Description: "Type of image, like \"Canon EOS 5D Mark IV\""},
+	{ID: 0x0007, IFD: "Canon", Name: "FirmwareVersion", Types: []uint16{TypeASCII},
+		Description: "Firmware version of the camera"},
+	{ID: 0x0008, IFD: "Canon", Name: "FileNumber", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Number of the file, as used in its name"},
+	{ID: 0x0009, IFD: "Canon", Name: "OwnerName", Types: []uint16{TypeASCII},
+		Description: "Name of the owner of the camera"},
+	{ID: 0x000C, IFD: "Canon", Name: "SerialNumber", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Serial number of the camera"},
+	{ID: 0x0010, IFD: "Canon", Name: "ModelID", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Identifier of the camera model"},
+	{ID: 0x0093, IFD: "Canon", Name: "FileInfo", Types: []uint16{TypeShort},
+		Description: "File information, like the bracketing settings"},
+	{ID: 0x0095, IFD: "Canon", Name: "LensModel", Types: []uint16{TypeASCII},
+		Description: "Name of the lens"},
+	{ID: 0x0096, IFD: "Canon", Name: "InternalSerialNumber", Types: []uint16{TypeASCII},
+		Description: "Internal serial number of the camera"},
+	// Values of CameraSettings, identified by their index
+	{ID: 5, IFD: "CanonCameraSettings", Name: "DriveMode", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0":  "single",
+			"1":  "continuous",
+			"2":  "movie",
+			"3":  "continuous, speed priority",
+			"4":  "continuous, low",
+			"5":  "continuous, high",
+			"6":  "silent single",
+			"8":  "continuous, high+",
+			"9":  "single, silent",
+			"10": "continuous, silent",
+		},
+		Description: "Drive mode"},
+	{ID: 7, IFD: "CanonCameraSettings", Name: "FocusMode", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0":   "one-shot AF",
+			"1":   "AI servo AF",
+			"2":   "AI focus AF",
+			"3":   "manual focus",
+			"4":   "single",
+			"5":   "continuous",
+			"6":   "manual focus",
+			"16":  "pan focus",
+			"256": "one-shot AF (live view)",
+			"257": "AI servo AF (live view)",
+			"258": "AI focus AF (live view)",
+			"512": "movie snap focus",
+			"519": "movie servo AF",
+		},
+		Description: "Focus mode"},
+}
@@ -0,0 +1,295 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NikonTags contains tags from the MakerNote of Nikon cameras.
+//
+// Only the format used since the D100 is decoded: the MakerNote starts with "Nikon\0", its version on 2 bytes,
+// 2 bytes of padding, followed by a complete TIFF header. Offsets in this MakerNote, as reported by warnings,
+// are counted from the start of this TIFF header.
+//
+// LensData and ShotInfo are encrypted by most cameras, using the serial number of the camera and the shutter count
+// as key. They are decrypted if both tags are recorded, and nil otherwise.
+type NikonTags struct {
+	MakerNoteVersion string
+	Quality          string
+	WhiteBalance     string
+	FocusMode        string
+	SerialNumber     string
+	ActiveDLighting  ActiveDLighting
+	LensType         NikonLensType
+	Lens             []Rational // minimum and maximum focal lengths, maximum apertures at these focal lengths
+	ShutterCount     uint32
+	LensData         []byte // decrypted, starting with its version on 4 bytes
+	ShotInfo         []byte // decrypted, starting with its version on 4 bytes, layout depends on the camera model
+
+	// Values decoded from the VRInfo and LensData tags
+	VibrationReduction VibrationReduction
+	LensID             string // see NikonTags.decodeLensID
+}
+
+// ActiveDLighting is the strength of the Active D-Lighting applied by the camera.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type ActiveDLighting uint16
+
+// Active D-Lighting strengths, as recorded by Nikon cameras
+const (
+	ActiveDLightingOff        ActiveDLighting = 0
+	ActiveDLightingLow        ActiveDLighting = 1
+	ActiveDLightingNormal     ActiveDLighting = 3
+	ActiveDLightingHigh       ActiveDLighting = 5
+	ActiveDLightingExtraHigh  ActiveDLighting = 7
+	ActiveDLightingExtraHigh1 ActiveDLighting = 8
+	ActiveDLightingExtraHigh2 ActiveDLighting = 9
+	ActiveDLightingExtraHigh3 ActiveDLighting = 10
+	ActiveDLightingExtraHigh4 ActiveDLighting = 11
+	ActiveDLightingAuto       ActiveDLighting = 0xFFFF
+)
+
+func (v ActiveDLighting) String() string {
+	return describeValue("Nikon", 0x0022, "ActiveDLighting", uint16(v))
+}
+
+// NikonLensType describes the features of a lens, one per bit.
+type NikonLensType uint8
+
+var nikonLensFeatures = []string{"MF", "D", "G", "VR", "1", "FT-1", "E", "AF-P"}
+
+// String returns the features of the lens separated by spaces, like "G VR".
+func (v NikonLensType) String() string {
+	var features []string
+	for i, name := range nikonLensFeatures {
+		if v&(1<<i) != 0 {
+			features = append(features, name)
+		}
+	}
+	return strings.Join(features, " ")
+}
+
+// VibrationReduction is the state of the vibration reduction of the lens or of the camera.
+type VibrationReduction uint8
+
+// Vibration reduction states, as recorded by Nikon cameras
+const (
+	VibrationReductionNotAvailable VibrationReduction = 0
+	VibrationReductionOn           VibrationReduction = 1
+	VibrationReductionOff          VibrationReduction = 2
+)
+
+func (v VibrationReduction) String() string {
+	return describeValue("Nikon", 0x001F, "VibrationReduction", uint8(v))
+}
+
+var nikonSignature = []byte("Nikon\x00")
+
+// readNikonMakerNote decodes a Nikon MakerNote and adds its IFD to x.IFDs as "Nikon".
+// MakerNotes in older formats, without TIFF header, are ignored.
+func (f *tiffFile) readNikonMakerNote(x *Exif, tag Tag) {
+	if len(tag.Data) < 10 || tag.Data[6] < 2 {
+		return
+	}
+
+	data := tag.Data[10:]
+	mn := &tiffFile{r: bytes.NewReader(data), size: uint64(len(data)), visited: make(map[uint64]bool), strict: f.strict, x: x}
+	if err := mn.readIFH(); err != nil {
+		var e *FormatError
+		if !errors.As(err, &e) {
+			e = tiffError("", 0, 0, err)
+		}
+		e.IFD = "Nikon"
+		f.addWarning(e)
+		return
+	}
+	ifd, err := mn.readIFD("Nikon", mn.offset0)
+	if err != nil {
+		f.addWarning(err)
+		return
+	}
+	x.IFDs[ifd.Name] = ifd
+
+	t := &NikonTags{}
+	mn.decodeTags(ifd, t)
+	t.decrypt(ifd, x.Image.Model)
+	if vr, ok := ifd.Tag(0x001F); ok && len(vr.Data) > 4 { // VRInfo, starting with its version on 4 bytes
+		t.VibrationReduction = VibrationReduction(vr.Data[4])
+	}
+	t.decodeLensID(mn.bo)
+	x.Nikon = t
+}
+
+// decrypt decrypts LensData and ShotInfo in place, or discards them if they can not be decrypted.
+// Versions 01xx of these tags are not encrypted.
+func (t *NikonTags) decrypt(ifd *IFD, model string) {
+	_, hasSerial := ifd.Tag(0x001D)
+	_, hasCount := ifd.Tag(0x00A7)
+	serial := nikonSerialKey(t.SerialNumber, model)
+
+	for _, data := range []*[]byte{&t.LensData, &t.ShotInfo} {
+		switch {
+		case len(*data) < 4 || bytes.HasPrefix(*data, []byte("01")):
+		case hasSerial && hasCount:
+			nikonDecrypt((*data)[4:], serial, t.ShutterCount)
+		default:
+			*data = nil
+		}
+	}
+}
+
+// decodeLensID sets LensID from LensData:
+//   - for F-mount lenses, the 8 bytes identifying the lens in lens databases like the one of ExifTool,
+//     formatted in hexadecimal like "7A 3C 1F 37 30 30 7E 0E"
+//   - for Z-mount lenses, recorded in LensData since version 0800, the lens number formatted in decimal
+func (t *NikonTags) decodeLensID(bo binary.ByteOrder) {
+	d := t.LensData
+	if len(d) < 4 {
+		return
+	}
+
+	start := 0 // offset of the F-mount lens identifier
+	switch version := string(d[:4]); {
+	case version == "0100":
+		start = 6
+	case version == "0101", version == "0201", version == "0202", version == "0203":
+		start = 11
+	case version == "0204":
+		start = 12
+	case version >= "0800" && len(d) >= 0x32:
+		if id := bo.Uint16(d[0x30:]); id != 0 {
+			t.LensID = strconv.Itoa(int(id))
+		}
+		return
+	}
+	if start == 0 || len(d) < start+7 {
+		return
+	}
+	id := append(append([]byte(nil), d[start:start+7]...), byte(t.LensType))
+	t.LensID = fmt.Sprintf("% X", id)
+}
+
+// nikonSerialKey returns the serial number used as key for decryption.
+// Serial numbers which are not numeric are replaced by a constant, depending of the model.
+func nikonSerialKey(serial, model string) uint32 {
+	if n, err := strconv.ParseUint(serial, 10, 32); err == nil {
+		return uint32(n)
+	}
+	if strings.HasSuffix(model, "D50") {
+		return 0x22
+	}
+	return 0x60
+}
+
+// nikonDecrypt decrypts data in place, using the algorithm found by Dave Coffin for dcraw.
+// Encryption being a XOR with a keystream, the same function also encrypts.
+func nikonDecrypt(data []byte, serial, count uint32) {
+	key := byte(count ^ count>>8 ^ count>>16 ^ count>>24)
+	ci := nikonXlat[0][serial&0xff]
+	cj := nikonXlat[1][key]
+	ck := byte(0x60)
+	for i := range data {
+		cj += ci * ck
+		ck++
+		data[i] ^= cj
+	}
+}
+
+var nikonXlat = [2][256]byte{
+	{0xc1, 0xbf, 0x6d, 0x0d, 0x59, 0xc5, 0x13, 0x9d, 0x83, 0x61, 0x6b, 0x4f, 0xc7, 0x7f, 0x3d, 0x3d,
+		0x53, 0x59, 0xe3, 0xc7, 0xe9, 0x2f, 0x95, 0xa7, 0x95, 0x1f, 0xdf, 0x7f, 0x2b, 0x29, 0xc7, 0x0d,
+		0xdf, 0x07, 0xef, 0x71, 0x89, 0x3d, 0x13, 0x3d, 0x3b, 0x13, 0xfb, 0x0d, 0x89, 0xc1, 0x65, 0x1f,
+		0xb3, 0x0d, 0x6b, 0x29, 0xe3, 0xfb, 0xef, 0xa3, 0x6b, 0x47, 0x7f, 0x95, 0x35, 0xa7, 0x47, 0x4f,
+		0xc7, 0xf1, 0x59, 0x95, 0x35, 0x11, 0x29, 0x61, 0xf1, 0x3d, 0xb3, 0x2b, 0x0d, 0x43, 0x89, 0xc1,
+		0x9d, 0x9d, 0x89, 0x65, 0xf1, 0xe9, 0xdf, 0xbf, 0x3d, 0x7f, 0x53, 0x97, 0xe5, 0xe9, 0x95, 0x17,
+		0x1d, 0x3d, 0x8b, 0xfb, 0xc7, 0xe3, 0x67, 0xa7, 0x07, 0xf1, 0x71, 0xa7, 0x53, 0xb5, 0x29, 0x89,
+		0xe5, 0x2b, 0xa7, 0x17, 0x29, 0xe9, 0x4f, 0xc5, 0x65, 0x6d, 0x6b, 0xef, 0x0d, 0x89, 0x49, 0x2f,
+		0xb3, 0x43, 0x53, 0x65, 0x1d, 0x49, 0xa3, 0x13, 0x89, 0x59, 0xef, 0x6b, 0xef, 0x65, 0x1d, 0x0b,
+		0x59, 0x13, 0xe3, 0x4f, 0x9d, 0xb3, 0x29, 0x43, 0x2b, 0x07, 0x1d, 0x95, 0x59, 0x59, 0x47, 0xfb,
+		0xe5, 0xe9, 0x61, 0x47, 0x2f, 0x35, 0x7f, 0x17, 0x7f, 0xef, 0x7f, 0x95, 0x95, 0x71, 0xd3, 0xa3,
+		0x0b, 0x71, 0xa3, 0xad, 0x0b, 0x3b, 0xb5, 0xfb, 0xa3, 0xbf, 0x4f, 0x83, 0x1d, 0xad, 0xe9, 0x2f,
+		0x71, 0x65, 0xa3, 0xe5, 0x07, 0x35, 0x3d, 0x0d, 0xb5, 0xe9, 0xe5, 0x47, 0x3b, 0x9d, 0xef, 0x35,
+		0xa3, 0xbf, 0xb3, 0xdf, 0x53, 0xd3, 0x97, 0x53, 0x49, 0x71, 0x07, 0x35, 0x61, 0x71, 0x2f, 0x43,
+		0x2f, 0x11, 0xdf, 0x17, 0x97, 0xfb, 0x95, 0x3b, 0x7f, 0x6b, 0xd3, 0x25, 0xbf, 0xad, 0xc7, 0xc5,
+		0xc5, 0xb5, 0x8b, 0xef, 0x2f, 0xd3, 0x07, 0x6b, 0x25, 0x49, 0x95, 0x25, 0x49, 0x6d, 0x71, 0xc7},
+	{0xa7, 0xbc, 0xc9, 0xad, 0x91, 0xdf, 0x85, 0xe5, 0xd4, 0x78, 0xd5, 0x17, 0x46, 0x7c, 0x29, 0x4c,
+		0x4d, 0x03, 0xe9, 0x25, 0x68, 0x11, 0x86, 0xb3, 0xbd, 0xf7, 0x6f, 0x61, 0x22, 0xa2, 0x26, 0x34,
+		0x2a, 0xbe, 0x1e, 0x46, 0x14, 0x68, 0x9d, 0x44, 0x18, 0xc2, 0x40, 0xf4, 0x7e, 0x5f, 0x1b, 0xad,
+		0x0b, 0x94, 0xb6, 0x67, 0xb4, 0x0b, 0xe1, 0xea, 0x95, 0x9c, 0x66, 0xdc, 0xe7, 0x5d, 0x6c, 0x05,
+		0xda, 0xd5, 0xdf, 0x7a, 0xef, 0xf6, 0xdb, 0x1f, 0x82, 0x4c, 0xc0, 0x68, 0x47, 0xa1, 0xbd, 0xee,
+		0x39, 0x50, 0x56, 0x4a, 0xdd, 0xdf, 0xa5, 0xf8, 0xc6, 0xda, 0xca, 0x90, 0xca, 0x01, 0x42, 0x9d,
+		0x8b, 0x0c, 0x73, 0x43, 0x75, 0x05, 0x94, 0xde, 0x24, 0xb3, 0x80, 0x34, 0xe5, 0x2c, 0xdc, 0x9b,
+		0x3f, 0xca, 0x33, 0x45, 0xd0, 0xdb, 0x5f, 0xf5, 0x52, 0xc3, 0x21, 0xda, 0xe2, 0x22, 0x72, 0x6b,
+		0x3e, 0xd0, 0x5b, 0xa8, 0x87, 0x8c, 0x06, 0x5d, 0x0f, 0xdd, 0x09, 0x19, 0x93, 0xd0, 0xb9, 0xfc,
+		0x8b, 0x0f, 0x84, 0x60, 0x33, 0x1c, 0x9b, 0x45, 0xf1, 0xf0, 0xa3, 0x94, 0x3a, 0x12, 0x77, 0x33,
+		0x4d, 0x44, 0x78, 0x28, 0x3c, 0x9e, 0xfd, 0x65, 0x57, 0x16, 0x94, 0x6b, 0xfb, 0x59, 0xd0, 0xc8,
+		0x22, 0x36, 0xdb, 0xd2, 0x63, 0x98, 0x43, 0xa1, 0x04, 0x87, 0x86, 0xf7, 0xa6, 0x26, 0xbb, 0xd6,
+		0x59, 0x4d, 0xbf, 0x6a, 0x2e, 0xaa, 0x2b, 0xef, 0xe6, 0x78, 0xb6, 0x4e, 0xe0, 0x2f, 0xdc, 0x7c,
+		0xbe, 0x57, 0x19, 0x32, 0x7e, 0x2a, 0xd0, 0xb8, 0xba, 0x29, 0x00, 0x3c, 0x52, 0x7d, 0xa8, 0x49,
+		0x3b, 0x2d, 0xeb, 0x25, 0x49, 0xfa, 0xa3, 0xaa, 0x39, 0xa7, 0xc5, 0xa7, 0x50, 0x11, 0x36, 0xfb,
+		0xc6, 0x67, 0x4a, 0xf5, 0xa5, 0x12, 0x65, 0x7e, 0xb0, 0xdf, 0xaf, 0x4e, 0xb3, 0x61, 0x7f, 0x2f},
+}
+
+// trimmedASCII formats an ASCII value padded with spaces, as recorded by Nikon cameras.
+func trimmedASCII(t Tag) string {
+	return strings.TrimSpace(t.asciiToString())
+}
+
+var nikonTagDefs = []TagDef{
+	{ID: 0x0001, IFD: "Nikon", Name: "MakerNoteVersion", Types: []uint16{TypeUndefined}, Count: 4,
+		Description: "Version of the MakerNote"},
+	{ID: 0x0004, IFD: "Nikon", Name: "Quality", Types: []uint16{TypeASCII}, format: trimmedASCII,
+		Description: "Image quality setting"},
+	{ID: 0x0005, IFD: "Nikon", Name: "WhiteBalance", Types: []uint16{TypeASCII}, format: trimmedASCII,
+		Description: "White balance setting"},
+	{ID: 0x0007, IFD: "Nikon", Name: "FocusMode", Types: []uint16{TypeASCII}, format: trimmedASCII,
+		Description: "Focus mode, like AF-S, AF-C or MANUAL"},
+	{ID: 0x001D, IFD: "Nikon", Name: "SerialNumber", Types: []uint16{TypeASCII}, format: trimmedASCII,
+		Description: "Serial number of the camera, used to decrypt LensData and ShotInfo"},
+	{ID: 0x001F, IFD: "Nikon", Name: "VRInfo", Types: []uint16{TypeUndefined},
+		// described by the state of the vibration reduction, recorded after the version on 4 bytes
+		Values: map[string]string{
+			"0": "not available",
+			"1": "on",
+			"2": "off",
+		},
+		format: func(t Tag) string {
+			if len(t.Data) <= 4 {
+				return t.String()
+			}
+			return VibrationReduction(t.Data[4]).String()
+		},
+		Description: "Vibration reduction information"},
+	{ID: 0x0022, IFD: "Nikon", Name: "ActiveDLighting", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0":     "off",
+			"1":     "low",
+			"3":     "normal",
+			"5":     "high",
+			"7":     "extra high",
+			"8":     "extra high 1",
+			"9":     "extra high 2",
+			"10":    "extra high 3",
+			"11":    "extra high 4",
+			"65535": "auto",
+		},
+		Description: "Strength of the Active D-Lighting"},
+	{ID: 0x0083, IFD: "Nikon", Name: "LensType", Types: []uint16{TypeByte}, Count: 1,
+		format:      func(t Tag) string { return NikonLensType(t.Data[0]).String() },
+		Description: "Features of the lens"},
+	{ID: 0x0084, IFD: "Nikon", Name: "Lens", Types: []uint16{TypeRational}, Count: 4,
+		Description: "Minimum and maximum focal lengths, maximum apertures at these focal lengths"},
+	{ID: 0x0091, IFD: "Nikon", Name: "ShotInfo", Types: []uint16{TypeUndefined},
+		Description: "Shooting information, encrypted"},
+	{ID: 0x0098, IFD: "Nikon", Name: "LensData", Types: []uint16{TypeUndefined},
+		Description: "Lens information, encrypted"},
+	{ID: 0x00A7, IFD: "Nikon", Name: "ShutterCount", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Number of shutter releases, used to decrypt LensData and ShotInfo"},
+}
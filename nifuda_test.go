@@ -4,26 +4,41 @@
 package nifuda
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestExifFileError(t *testing.T) {
 	tests := []struct {
 		filepath string
+		err      error // expected sentinel error, nil if a FormatError is expected
 	}{
-		{"./testdata/errors/empty.txt"},
-		{"./testdata/errors/dummy.txt"},
-		{"./testdata/errors/nosoi.jpg"},
-		{"./testdata/errors/minimal.jpg"},
-		{"./testdata/errors/wrong_version.tif"},
-		{"./testdata/errors/wrong_offset0.tif"},
-		{"./testdata/errors/no_ifd0.tif"},
-		//{"./testdata/errors/recursive_ifd0.tif"},  FIXME
-		//{"./testdata/errors/wrong_ifd1.tif"}, FIXME
+		{"./testdata/errors/empty.txt", ErrNotExif},
+		{"./testdata/errors/dummy.txt", ErrNotExif},
+		{"./testdata/errors/nosoi.jpg", ErrNotExif},
+		{"./testdata/errors/minimal.jpg", ErrNoExifData},
+		{"./testdata/errors/wrong_version.tif", ErrUnsupportedFormat},
+		{"./testdata/errors/wrong_offset0.tif", nil},
+		{"./testdata/errors/no_ifd0.tif", nil},
+		{"./testdata/errors/recursive_ifd0.tif", nil},
+		{"./testdata/errors/wrong_ifd1.tif", nil},
+		{"./testdata/errors/wrong_offsets.tif", nil},
 	}
 
 	for _, tc := range tests {
@@ -36,6 +51,193 @@ func TestExifFileError(t *testing.T) {
 		f, err := Read(osf)
 		if err == nil || f != nil {
 			t.Errorf("%s: reading file should have failed and returned nil, err=%s, f=%v", tc.filepath, err, f)
+			continue
+		}
+
+		if tc.err != nil && !errors.Is(err, tc.err) {
+			t.Errorf("%s: error should be %s, got=%s", tc.filepath, tc.err, err)
+		}
+		var fe *FormatError
+		if tc.err == nil && !errors.As(err, &fe) {
+			t.Errorf("%s: error should be a FormatError, got=%s", tc.filepath, err)
+		}
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	filepath := "./testdata/errors/wrong_offsets.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	_, err = Read(f)
+	var fe *FormatError
+	if !errors.As(err, &fe) {
+		t.Fatalf("%s: error should be a FormatError, got=%s", filepath, err)
+	}
+	if fe.Container != "TIFF" || fe.IFD != "Exif" || fe.Tag != 36867 || fe.Offset != 9999 {
+		t.Errorf("%s: got=%+v", filepath, fe)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("%s: underlying error should be io.ErrUnexpectedEOF, got=%s", filepath, fe.Err)
+	}
+}
+
+func TestLenientRead(t *testing.T) {
+	filepath := "./testdata/errors/wrong_offsets.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := ReadWithOptions(f, Options{Strict: false})
+	if err != nil || x == nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	if x.Image.Make != "nifuda" || x.Photo.ExifVersion != "0231" || x.Photo.SubSecTime != "72" {
+		t.Errorf("%s: valid tags should have been decoded, got Image=%+v, Photo=%+v", filepath, x.Image, x.Photo)
+	}
+	if _, ok := x.IFDs["GPS"]; ok {
+		t.Errorf("%s: GPS IFD should not have been read", filepath)
+	}
+
+	want := []struct {
+		ifd    string
+		tag    uint16
+		offset uint64
+	}{
+		{"Exif", 36867, 9999},
+		{"GPS", 0, 9999},
+	}
+	if len(x.Warnings) != len(want) {
+		t.Fatalf("%s: got %d warnings, want=%d: %v", filepath, len(x.Warnings), len(want), x.Warnings)
+	}
+	for i, w := range want {
+		got := x.Warnings[i]
+		if got.IFD != w.ifd || got.Tag != w.tag || got.Offset != w.offset || got.Reason == "" {
+			t.Errorf("%s: warning %d, got=%+v, want=%+v", filepath, i, got, w)
+		}
+	}
+}
+
+// overlappingValuesTIFF returns a TIFF file of size bytes, whose IFD0 has the given number of entries
+// all pointing to the same value, which covers most of the file.
+func overlappingValuesTIFF(entries int, size int) []byte {
+	bo := binary.LittleEndian
+	data := make([]byte, size)
+	copy(data, "II*\x00")
+	bo.PutUint32(data[4:], 8)
+	bo.PutUint16(data[8:], uint16(entries))
+	for i := 0; i < entries; i++ {
+		entry := data[10+12*i:]
+		bo.PutUint16(entry, 65000)             // private tag
+		bo.PutUint16(entry[2:], TypeUndefined) // type
+		bo.PutUint32(entry[4:], uint32(size-8))
+		bo.PutUint32(entry[8:], 8)
+	}
+	return data
+}
+
+func TestReadLimits(t *testing.T) {
+	testcases := []struct {
+		name    string
+		entries int
+	}{
+		{"too many entries", 20000},
+		{"too many value bytes", 1000},
+	}
+	for _, tc := range testcases {
+		data := overlappingValuesTIFF(tc.entries, 256<<10)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := Read(bytes.NewReader(data))
+		runtime.ReadMemStats(&after)
+		var fe *FormatError
+		if !errors.As(err, &fe) || fe.IFD != "IFD0" {
+			t.Errorf("%s: strict mode got error=%v, want a FormatError for IFD0", tc.name, err)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 64<<20 {
+			t.Errorf("%s: strict mode allocated %d MB", tc.name, alloc>>20)
+		}
+
+		runtime.ReadMemStats(&before)
+		x, err := ReadWithOptions(bytes.NewReader(data), Options{Strict: false})
+		runtime.ReadMemStats(&after)
+		if err != nil || len(x.Warnings) == 0 {
+			t.Errorf("%s: lenient mode got error=%v, warnings=%v, want warnings only", tc.name, err, x)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 64<<20 {
+			t.Errorf("%s: lenient mode allocated %d MB", tc.name, alloc>>20)
+		}
+	}
+
+	// entries beyond the limit are skipped, but the offset of the next IFD is still read after all of them
+	entries := maxEntries + 904
+	data := make([]byte, 8+2+12*entries+4)
+	bo := binary.LittleEndian
+	copy(data, "II*\x00")
+	bo.PutUint32(data[4:], 8)
+	bo.PutUint16(data[8:], uint16(entries))
+	for i := 0; i < entries; i++ {
+		entry := data[10+12*i:]
+		bo.PutUint16(entry, 65000)         // private tag
+		bo.PutUint16(entry[2:], TypeShort) // type
+		bo.PutUint32(entry[4:], 1)
+	}
+	x, err := ReadWithOptions(bytes.NewReader(data), Options{Strict: false})
+	if err != nil {
+		t.Fatalf("%d entries: lenient mode got error=%v", entries, err)
+	}
+	if next := x.IFDs["IFD0"].Next; next != 0 {
+		t.Errorf("%d entries: got IFD0.Next=%d, want 0", entries, next)
+	}
+	if len(x.Warnings) != 1 || !strings.Contains(x.Warnings[0].Reason, "entries, limit is") {
+		t.Errorf("%d entries: got warnings=%v, want only the warning about the number of entries", entries, x.Warnings)
+	}
+}
+
+func TestReadAtConcurrent(t *testing.T) {
+	filepath := "./testdata/TEST_2019-07-21_132615_DSC_0361.NEF"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatalf("%s: stat failed, error=%s", filepath, err)
+	}
+
+	// reference decoded from an io.ReadSeeker which does not implement io.ReaderAt
+	want, err := Read(struct{ io.ReadSeeker }{f})
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*Exif, 8)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = ReadAt(f, info.Size())
+		}(i)
+	}
+	wg.Wait()
+
+	for i, x := range results {
+		if errs[i] != nil {
+			t.Errorf("%s: goroutine %d, reading exifs failed, error=%s", filepath, i, errs[i])
+			continue
+		}
+		if !reflect.DeepEqual(x, want) {
+			t.Errorf("%s: goroutine %d, got=%+v, want=%+v", filepath, i, x, want)
 		}
 	}
 }
@@ -101,6 +303,1062 @@ func TestReadTags(t *testing.T) {
 	}
 }
 
+func TestRawTags(t *testing.T) {
+	filepath := "./testdata/TEST_2018-05-14_095545.jpg"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	tests := []struct {
+		ifd      string
+		id       uint16
+		typeName string
+		count    uint64
+		data     string
+	}{
+		{"IFD0", 271, "ASCII", 9, "Motorola\x00"},
+		{"Exif", 36864, "UNDEFINED", 4, "0220"},
+		{"Exif", 42033, "ASCII", 10, "123456789\x00"},
+		{"GPS", 1, "ASCII", 2, "N\x00"},
+	}
+	for _, tc := range tests {
+		ifd, ok := x.IFDs[tc.ifd]
+		if !ok {
+			t.Errorf("%s: IFD %s not found", filepath, tc.ifd)
+			continue
+		}
+		tag, ok := ifd.Tag(tc.id)
+		if !ok {
+			t.Errorf("%s: tag %d not found in IFD %s", filepath, tc.id, tc.ifd)
+			continue
+		}
+		if tag.TypeName() != tc.typeName || tag.Count != tc.count || string(tag.Data) != tc.data {
+			t.Errorf("%s, %s.%d: got=(%s, %d, %q), want=(%s, %d, %q)", filepath, tc.ifd, tc.id,
+				tag.TypeName(), tag.Count, tag.Data, tc.typeName, tc.count, tc.data)
+		}
+	}
+}
+
+func TestIFDChain(t *testing.T) {
+	filepath := "./testdata/templates/minimal_with_ifd1.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	ifd0, ok := x.IFDs["IFD0"]
+	if !ok {
+		t.Fatalf("%s: IFD0 not found", filepath)
+	}
+	ifd1, ok := x.IFDs["IFD1"]
+	if !ok {
+		t.Fatalf("%s: IFD1 not found", filepath)
+	}
+	if ifd1.Offset != ifd0.Next {
+		t.Errorf("%s: IFD1 offset=%d, want=%d", filepath, ifd1.Offset, ifd0.Next)
+	}
+	if _, ok := x.IFDs["IFD2"]; ok {
+		t.Errorf("%s: IFD2 should not exist", filepath)
+	}
+}
+
+func TestIFDChainOrder(t *testing.T) {
+	filepath := "./testdata/chain.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	want := []string{"IFD0", "IFD1", "IFD2"}
+	if len(x.Chain) != len(want) {
+		t.Fatalf("%s: got %d IFDs in chain, want=%d", filepath, len(x.Chain), len(want))
+	}
+	for i, ifd := range x.Chain {
+		if ifd.Name != want[i] || x.IFDs[want[i]] != ifd {
+			t.Errorf("%s: chain[%d] got=%s, want=%s", filepath, i, ifd.Name, want[i])
+		}
+		if length := ifd.Tags[0].uintValues()[0]; length != uint64(i) { // ImageLength records the position in chain
+			t.Errorf("%s: %s ImageLength got=%d, want=%d", filepath, ifd.Name, length, i)
+		}
+		if i > 0 && ifd.Offset != x.Chain[i-1].Next {
+			t.Errorf("%s: %s offset=%d, want=%d", filepath, ifd.Name, ifd.Offset, x.Chain[i-1].Next)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	testcases := []string{
+		"./testdata/thumbnail.tif",
+		"./testdata/thumbnail.jpg",
+	}
+	want := []byte{0xff, 0xd8, 0xff, 0xd9}
+
+	for _, filepath := range testcases {
+		f, err := os.Open(filepath)
+		if err != nil {
+			t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+		}
+		defer f.Close()
+
+		x, err := Read(f)
+		if err != nil {
+			t.Errorf("%s: reading exifs failed, error=%s", filepath, err)
+			continue
+		}
+
+		got, err := x.Thumbnail()
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s: got=%v, want=%v, error=%s", filepath, got, want, err)
+		}
+
+		// dimensions are recorded either as SHORT or LONG
+		if x.Image.ImageWidth != 640 || x.Image.ImageLength != 480 {
+			t.Errorf("%s: image got=%dx%d, want=640x480", filepath, x.Image.ImageWidth, x.Image.ImageLength)
+		}
+		if x.ThumbnailImage.ImageWidth != 160 || x.ThumbnailImage.ImageLength != 120 {
+			t.Errorf("%s: thumbnail got=%dx%d, want=160x120", filepath, x.ThumbnailImage.ImageWidth, x.ThumbnailImage.ImageLength)
+		}
+		if x.ThumbnailImage.Compression != CompressionJPEGThumbnail {
+			t.Errorf("%s: thumbnail compression got=%s, want=%s", filepath, x.ThumbnailImage.Compression, CompressionJPEGThumbnail)
+		}
+		if x.ThumbnailImage.JPEGInterchangeFormatLength != uint64(len(want)) {
+			t.Errorf("%s: thumbnail length got=%d, want=%d", filepath, x.ThumbnailImage.JPEGInterchangeFormatLength, len(want))
+		}
+	}
+
+	// no thumbnail
+	filepath := "./testdata/TEST_2018-05-14_095545.jpg"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+	if got, err := x.Thumbnail(); err == nil {
+		t.Errorf("%s: should have no thumbnail, got=%v", filepath, got)
+	}
+
+	// thumbnail out of bounds, ignored with a warning even in strict mode
+	filepath = "./testdata/thumbnail.tif"
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		t.Fatalf("%s: reading file failed, error=%s", filepath, err)
+	}
+	setEntryValue(t, data, binary.BigEndian, 513, TypeLong, 1, 0x7fffffff) // JPEGInterchangeFormat
+	x, err = Read(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+	if got, err := x.Thumbnail(); err != ErrNoThumbnail {
+		t.Errorf("%s: got=%v, error=%v, want error=%s", filepath, got, err, ErrNoThumbnail)
+	}
+	if len(x.Warnings) != 1 || x.Warnings[0].IFD != "IFD1" || x.Warnings[0].Tag != 513 {
+		t.Errorf("%s: got warnings=%+v, want one warning for IFD1 tag 513", filepath, x.Warnings)
+	}
+	if x.Image.ImageWidth != 640 {
+		t.Errorf("%s: image width got=%d, want=640", filepath, x.Image.ImageWidth)
+	}
+}
+
+// setEntryValue overwrites the value of the first entry of a classic TIFF file with the given identifier, type and count.
+func setEntryValue(t *testing.T, data []byte, bo binary.ByteOrder, id, typ uint16, count, value uint32) {
+	t.Helper()
+	entry := make([]byte, 8)
+	bo.PutUint16(entry, id)
+	bo.PutUint16(entry[2:], typ)
+	bo.PutUint32(entry[4:], count)
+	i := bytes.Index(data, entry)
+	if i < 0 {
+		t.Fatalf("entry for tag %d not found", id)
+	}
+	bo.PutUint32(data[i+8:], value)
+}
+
+func TestInteropTags(t *testing.T) {
+	filepath := "./testdata/interop.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	want := InteropTags{
+		InteroperabilityIndex:   "R98",
+		InteroperabilityVersion: "0100",
+		RelatedImageFileFormat:  "Exif JPEG",
+		RelatedImageWidth:       640,
+		RelatedImageLength:      480,
+	}
+	if x.Interop != want {
+		t.Errorf("%s: got=%+v, want=%+v", filepath, x.Interop, want)
+	}
+	if x.Image.InteroperabilityIFD != x.IFDs["Interop"].Offset {
+		t.Errorf("%s: InteroperabilityIFD=%d, want=%d", filepath, x.Image.InteroperabilityIFD, x.IFDs["Interop"].Offset)
+	}
+}
+
+func TestSubIFDs(t *testing.T) {
+	filepath := "./testdata/subifds.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	want := []SubIFDTags{
+		{
+			Name:           "IFD0.SubIFD0",
+			ImageWidth:     6048,
+			ImageLength:    4024,
+			Compression:    CompressionNikonNEF,
+			TileWidth:      256,
+			TileLength:     256,
+			TileOffsets:    []uint64{100000, 200000, 300000},
+			TileByteCounts: []uint64{100000, 100000, 100000},
+		},
+		{
+			Name:            "IFD0.SubIFD0.SubIFD0",
+			NewSubfileType:  1,
+			ImageWidth:      160,
+			ImageLength:     120,
+			Compression:     CompressionUncompressed,
+			StripOffsets:    []uint64{1000, 29800},
+			RowsPerStrip:    60,
+			StripByteCounts: []uint64{28800, 28800},
+		},
+		{
+			Name:           "IFD0.SubIFD1",
+			NewSubfileType: 1,
+			Compression:    CompressionJPEGThumbnail,
+		},
+	}
+	if !reflect.DeepEqual(x.SubIFDs, want) {
+		t.Errorf("%s: got=%+v, want=%+v", filepath, x.SubIFDs, want)
+	}
+	for _, s := range want {
+		if _, ok := x.IFDs[s.Name]; !ok {
+			t.Errorf("%s: IFD %s not found", filepath, s.Name)
+		}
+	}
+}
+
+func TestBigTIFF(t *testing.T) {
+	filepath := "./testdata/bigtiff.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	if x.Image.Make != "nifuda" || x.Image.Artist != "Pink Panther" {
+		t.Errorf("%s: got Make=%s, Artist=%s", filepath, x.Image.Make, x.Image.Artist)
+	}
+	if x.Photo.ExifVersion != "0231" {
+		t.Errorf("%s: got ExifVersion=%s", filepath, x.Photo.ExifVersion)
+	}
+	if tag, ok := x.IFDs["IFD0"].Tag(34665); !ok || tag.TypeName() != "IFD8" {
+		t.Errorf("%s: Exif IFD pointer should have type IFD8, got=%s", filepath, tag.TypeName())
+	}
+
+	// image data layout recorded as LONG8, with offsets beyond 4 GiB
+	if x.Image.ImageWidth != 6048 || x.Image.ImageLength != 4024 || x.Image.RowsPerStrip != 2012 {
+		t.Errorf("%s: image got=%dx%d, %d rows per strip", filepath, x.Image.ImageWidth, x.Image.ImageLength, x.Image.RowsPerStrip)
+	}
+	if want := []uint64{1 << 32, 1<<32 + 4096}; !reflect.DeepEqual(x.Image.StripOffsets, want) {
+		t.Errorf("%s: StripOffsets got=%v, want=%v", filepath, x.Image.StripOffsets, want)
+	}
+	if want := []uint64{4096, 4096}; !reflect.DeepEqual(x.Image.StripByteCounts, want) {
+		t.Errorf("%s: StripByteCounts got=%v, want=%v", filepath, x.Image.StripByteCounts, want)
+	}
+	if got, err := x.Thumbnail(); err != nil || !bytes.Equal(got, []byte{0xff, 0xd8, 0xff, 0xd9}) {
+		t.Errorf("%s: thumbnail got=%v, error=%v", filepath, got, err)
+	}
+	if x.ThumbnailImage.JPEGInterchangeFormatLength != 4 {
+		t.Errorf("%s: thumbnail length got=%d, want=4", filepath, x.ThumbnailImage.JPEGInterchangeFormatLength)
+	}
+	if len(x.Warnings) != 0 {
+		t.Errorf("%s: unexpected warnings %v", filepath, x.Warnings)
+	}
+}
+
+func TestRegisterTag(t *testing.T) {
+	filepath := "./testdata/private.tif"
+
+	defs := []TagDef{
+		{ID: 65000, IFD: "IFD0", Name: "PrivateText", Types: []uint16{TypeASCII}},
+		{ID: 65001, IFD: "IFD0", Name: "PrivateMode", Types: []uint16{TypeShort}, Count: 1,
+			Values: map[string]string{"1": "off", "2": "on"}},
+	}
+	for _, def := range defs {
+		if _, ok := LookupTag(def.IFD, def.ID); ok { // already registered by a previous run of the test
+			continue
+		}
+		if err := RegisterTag(def); err != nil {
+			t.Fatalf("registering %s failed, error=%s", def.Name, err)
+		}
+	}
+
+	errorTests := []TagDef{
+		{ID: 65000, IFD: "IFD0", Name: "Duplicate"},       // identifier already defined
+		{ID: 65002, IFD: "IFD0", Name: "PrivateText"},     // name already defined
+		{ID: 65002, IFD: "IFD1", Name: "PrivateInIFD1"},   // IFD1 shares the definitions of IFD0
+		{ID: 65002, IFD: "MakerNote", Name: "UnknownIFD"}, // unknown IFD
+		{ID: 65002, IFD: "IFD0"},                          // no name
+	}
+	for _, def := range errorTests {
+		if err := RegisterTag(def); err == nil {
+			t.Errorf("registering %+v should fail", def)
+		}
+	}
+
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := ReadWithOptions(f, Options{})
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	tag, ok := x.Tag("IFD0", "PrivateText")
+	if !ok || tag.Value() != "private" {
+		t.Errorf("%s: PrivateText got=%v, want=private", filepath, tag.Value())
+	}
+	tag, ok = x.Tag("IFD0", "PrivateMode")
+	def, _ := LookupTag("IFD0", tag.ID)
+	if !ok || def.Describe(tag) != "on" {
+		t.Errorf("%s: PrivateMode got=%s, want=on", filepath, def.Describe(tag))
+	}
+	if def, ok := LookupTagByName("IFD1", "Make"); !ok || def.ID != 271 {
+		t.Errorf("Make should be defined for IFD1, got=%+v", def)
+	}
+
+	// BitsPerSample is recorded as LONG instead of SHORT
+	if x.Image.BitsPerSample != 0 {
+		t.Errorf("%s: BitsPerSample got=%d, want=0", filepath, x.Image.BitsPerSample)
+	}
+	if len(x.Warnings) != 1 || x.Warnings[0].Tag != 258 {
+		t.Errorf("%s: got warnings=%v, want a warning for tag 258", filepath, x.Warnings)
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	tests := []struct {
+		value fmt.Stringer
+		want  string
+	}{
+		{CompressionJPEG, "JPEG"},
+		{PhotometricYCbCr, "YCbCr"},
+		{PlanarConfigurationChunky, "chunky format"},
+		{YCbCrCoSited, "co-sited"},
+		{ResolutionUnitCentimeters, "centimeters"},
+		{ExposureProgramAperturePriority, "aperture priority"},
+		{ExposureProgram(42), "ExposureProgram(42)"},
+		{MeteringModeOther, "other"},
+		{LatitudeSouth, "South"},
+		{LongitudeWest, "West"},
+		{AltitudeBelowSeaLevel, "Sea level reference (negative value)"},
+		{GPSMeasurementInterrupted, "Measurement interrupted"},
+		{GPSMeasurement3D, "3-dimensional measurement"},
+		{SpeedKnots, "Knots"},
+		{DirectionTrue, "True direction"},
+		{DistanceNauticalMiles, "Nautical miles"},
+		{LatitudeRef("X"), `LatitudeRef("X")`},
+		{OrientationRightTop, "rotate 90 CW"},
+		{SensitivityTypeREI, "recommended exposure index"},
+		{LightSourceD65, "D65"},
+		{Flash(0x19), "flash fired, auto mode"},
+		{FileSourceDigitalStillCamera, "DSC"},
+		{WhiteBalanceManual, "manual white balance"},
+		{SubjectDistanceRangeMacro, "macro"},
+		{ColorSpaceUncalibrated, "uncalibrated"},
+		{ComponentsConfiguration{4, 5, 6, 0}, "R, G, B, -"},
+		{ActiveDLightingAuto, "auto"},
+		{VibrationReductionOff, "off"},
+		{VibrationReduction(9), "VibrationReduction(9)"},
+		{CanonDriveMode(5), "continuous, high"},
+		{CanonFocusMode(519), "movie servo AF"},
+		{CanonFocusMode(42), "CanonFocusMode(42)"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.value.String(); got != tc.want {
+			t.Errorf("%#v: got=%s, want=%s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestFlash(t *testing.T) {
+	tests := []struct {
+		flash         Flash
+		fired, redEye bool
+	}{
+		{0x00, false, false},
+		{0x19, true, false},
+		{0x20, false, false},
+		{0x14, false, false},
+		{0x58, false, true},
+		{0x5f, true, true},
+	}
+
+	for _, tc := range tests {
+		if tc.flash.Fired() != tc.fired || tc.flash.RedEyeReduction() != tc.redEye {
+			t.Errorf("%#x: got Fired=%t, RedEyeReduction=%t", uint16(tc.flash), tc.flash.Fired(), tc.flash.RedEyeReduction())
+		}
+	}
+
+	// descriptions agree with Fired
+	def, _ := LookupTag("Exif", 37385)
+	for k, desc := range def.Values {
+		v, _ := strconv.Atoi(k)
+		if fired := strings.HasPrefix(desc, "flash fired"); fired != Flash(v).Fired() {
+			t.Errorf("%s: %q does not agree with Fired=%t", k, desc, Flash(v).Fired())
+		}
+	}
+}
+
+func TestISO(t *testing.T) {
+	tests := []struct {
+		tags PhotoTags
+		want uint32
+	}{
+		{PhotoTags{PhotographicSensitivity: 100}, 100},
+		{PhotoTags{PhotographicSensitivity: 65535, SensitivityType: SensitivityTypeSOS, StandardOutputSensitivity: 102400}, 102400},
+		{PhotoTags{PhotographicSensitivity: 65535, SensitivityType: SensitivityTypeREI, RecommendedExposureIndex: 204800}, 204800},
+		{PhotoTags{PhotographicSensitivity: 65535, SensitivityType: SensitivityTypeISOSpeed, ISOSpeed: 409600}, 409600},
+		{PhotoTags{PhotographicSensitivity: 65535}, 65535},
+	}
+
+	for _, tc := range tests {
+		if got := tc.tags.ISO(); got != tc.want {
+			t.Errorf("%+v: got=%d, want=%d", tc.tags, got, tc.want)
+		}
+	}
+}
+
+func TestNikonMakerNote(t *testing.T) {
+	filepath := "./testdata/TEST_2019-07-21_132615_DSC_0361.NEF"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+	if x.Nikon == nil {
+		t.Fatalf("%s: Nikon MakerNote not decoded", filepath)
+	}
+	if _, ok := x.IFDs["Nikon"]; !ok {
+		t.Errorf("%s: IFD Nikon not found", filepath)
+	}
+
+	n := x.Nikon
+	testcases := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"Quality", n.Quality, "RAW"},
+		{"FocusMode", n.FocusMode, "AF-S"},
+		{"SerialNumber", n.SerialNumber, "6018823"},
+		{"ShutterCount", n.ShutterCount, uint32(362)},
+		{"ActiveDLighting", n.ActiveDLighting.String(), "off"},
+		{"VibrationReduction", n.VibrationReduction, VibrationReductionOn},
+		{"Lens", n.Lens, []Rational{{240, 10}, {700, 10}, {400, 100}, {400, 100}}},
+		{"LensID", n.LensID, "1"},
+	}
+	for _, tc := range testcases {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Errorf("%s: %s got=%v, want=%v", filepath, tc.name, tc.got, tc.want)
+		}
+	}
+	// VRInfo is described by the state of the vibration reduction, with translations
+	def, _ := LookupTag("Nikon", 0x001F)
+	vr, _ := x.IFDs["Nikon"].Tag(0x001F)
+	if got, want := NewPrinter("fr").Describe(def, vr), "activé"; got != want {
+		t.Errorf("%s: VRInfo described as %s, want=%s", filepath, got, want)
+	}
+	// decrypted ShotInfo starts with the firmware version
+	if len(n.ShotInfo) < 9 || string(n.ShotInfo[4:9]) != "02.00" {
+		t.Errorf("%s: ShotInfo not decrypted", filepath)
+	}
+
+	// other cameras have no Nikon MakerNote
+	other := "./testdata/TEST_2018-05-14_095545.jpg"
+	g, err := os.Open(other)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", other, err)
+	}
+	defer g.Close()
+	if x, err = Read(g); err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", other, err)
+	}
+	if x.Nikon != nil {
+		t.Errorf("%s: unexpected Nikon MakerNote %+v", other, x.Nikon)
+	}
+}
+
+func TestCanonMakerNote(t *testing.T) {
+	filepath := "./testdata/canon.tif"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+	if x.Nikon != nil {
+		t.Errorf("%s: unexpected Nikon MakerNote", filepath)
+	}
+	if _, ok := x.IFDs["Canon"]; !ok {
+		t.Errorf("%s: IFD Canon not found", filepath)
+	}
+
+	settings := make([]uint16, 25)
+	settings[0], settings[5], settings[7] = 50, 1, 1
+	want := &CanonTags{
+		CameraSettings:       settings,
+		ShotInfo:             []uint16{8, 0, 160, 5},
+		ImageType:            "Canon EOS 5D Mark IV",
+		FirmwareVersion:      "Firmware 1.0.4",
+		FileNumber:           1001234,
+		SerialNumber:         12345678,
+		FileInfo:             []uint16{6, 0, 1},
+		LensModel:            "EF24-105mm f/4L IS USM",
+		InternalSerialNumber: "XA1234567",
+		DriveMode:            1,
+		FocusMode:            1,
+	}
+	if !reflect.DeepEqual(x.Canon, want) {
+		t.Errorf("%s: got=%+v, want=%+v", filepath, x.Canon, want)
+	}
+	if got, want := x.Canon.DriveMode.String(), "continuous"; got != want {
+		t.Errorf("%s: DriveMode got=%s, want=%s", filepath, got, want)
+	}
+	if got, want := x.Canon.FocusMode.String(), "AI servo AF"; got != want {
+		t.Errorf("%s: FocusMode got=%s, want=%s", filepath, got, want)
+	}
+
+	// damaged MakerNote, ignored with a warning even in strict mode
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		t.Fatalf("%s: reading file failed, error=%s", filepath, err)
+	}
+	setEntryValue(t, data, binary.LittleEndian, 0x0006, TypeASCII, 21, 0x7fffffff) // ImageType
+	x, err = Read(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+	if x.Canon != nil {
+		t.Errorf("%s: Canon MakerNote should not be decoded, got=%+v", filepath, x.Canon)
+	}
+	if len(x.Warnings) != 1 || x.Warnings[0].IFD != "Canon" || x.Warnings[0].Tag != 0x0006 {
+		t.Errorf("%s: got warnings=%v, want one warning for Canon tag 6", filepath, x.Warnings)
+	}
+	if x.Image.Model != "Canon EOS 5D Mark IV" || x.Photo.ExifVersion != "0231" {
+		t.Errorf("%s: other tags should be decoded, got Model=%q, ExifVersion=%q", filepath, x.Image.Model, x.Photo.ExifVersion)
+	}
+}
+
+func TestDamagedNikonMakerNote(t *testing.T) {
+	filepath := "./testdata/TEST_2019-07-21_132615_DSC_0361.NEF"
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		t.Fatalf("%s: reading file failed, error=%s", filepath, err)
+	}
+	mn := bytes.Index(data, []byte("Nikon\x00\x02"))
+	if mn < 0 {
+		t.Fatalf("%s: Nikon MakerNote not found", filepath)
+	}
+	header := mn + 10 // TIFF header of the MakerNote, little endian in this file
+
+	testcases := []struct {
+		name  string
+		patch func(d []byte)
+	}{
+		{"garbled byte order", func(d []byte) { copy(d[header:], "XX") }},
+		{"IFD out of MakerNote", func(d []byte) { binary.LittleEndian.PutUint32(d[header+4:], 0x7fffffff) }},
+		{"truncated IFD", func(d []byte) {
+			ifd := header + int(binary.LittleEndian.Uint32(d[header+4:]))
+			binary.LittleEndian.PutUint16(d[ifd:], 0xffff)
+		}},
+	}
+	for _, tc := range testcases {
+		d := append([]byte(nil), data...)
+		tc.patch(d)
+
+		x, err := Read(bytes.NewReader(d)) // strict mode
+		if err != nil {
+			t.Errorf("%s: reading exifs failed, error=%s", tc.name, err)
+			continue
+		}
+		if x.Nikon != nil {
+			t.Errorf("%s: Nikon MakerNote should not be decoded", tc.name)
+		}
+		if _, ok := x.IFDs["Nikon"]; ok {
+			t.Errorf("%s: IFD Nikon should not be read", tc.name)
+		}
+		if len(x.Warnings) != 1 || x.Warnings[0].IFD != "Nikon" {
+			t.Errorf("%s: got warnings=%v, want one warning for Nikon", tc.name, x.Warnings)
+		}
+		if x.Image.Make != "NIKON CORPORATION" || len(x.Photo.MakerNote) == 0 {
+			t.Errorf("%s: other tags should be decoded, got Make=%q", tc.name, x.Image.Make)
+		}
+	}
+}
+
+func TestNikonDecrypt(t *testing.T) {
+	data := []byte("02.00.00")
+	nikonDecrypt(data, 6018823, 362)
+	nikonDecrypt(data, 6018823, 362)
+	if string(data) != "02.00.00" {
+		t.Errorf("encrypting then decrypting got=%q", data)
+	}
+	if got, want := nikonSerialKey("", "NIKON D50"), uint32(0x22); got != want {
+		t.Errorf("got=%#x, want=%#x", got, want)
+	}
+}
+
+func TestCoordinate(t *testing.T) {
+	c := Coordinate{Degrees: Rational{33, 1}, Minutes: Rational{51, 1}, Seconds: Rational{2160, 100}}
+	for _, tc := range []struct {
+		ref  CoordinateRef
+		want float64
+	}{
+		{nil, 33.856},
+		{LatitudeNorth, 33.856},
+		{LatitudeSouth, -33.856},
+		{LongitudeEast, 33.856},
+		{LongitudeWest, -33.856},
+		{LatitudeRef(""), 33.856}, // reference not recorded
+	} {
+		if got := c.DecimalDegrees(tc.ref); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s %v: got=%f, want=%f", c, tc.ref, got, tc.want)
+		}
+	}
+	if got, want := c.String(), "33° 51' 21.6\""; got != want {
+		t.Errorf("got=%s, want=%s", got, want)
+	}
+
+	// sign given by the reference tags
+	g := GpsTags{GPSLatitudeRef: LatitudeSouth, GPSLatitude: c, GPSLongitudeRef: LongitudeWest, GPSLongitude: c,
+		GPSDestLatitudeRef: LatitudeNorth, GPSDestLatitude: c, GPSDestLongitudeRef: LongitudeEast, GPSDestLongitude: c}
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Latitude", g.Latitude(), -33.856},
+		{"Longitude", g.Longitude(), -33.856},
+		{"DestLatitude", g.DestLatitude(), 33.856},
+		{"DestLongitude", g.DestLongitude(), 33.856},
+	} {
+		if math.Abs(tc.got-tc.want) > 1e-9 {
+			t.Errorf("%s: got=%f, want=%f", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	testcases := []struct {
+		filepath      string
+		lat, lon, alt float64
+		ok            bool
+	}{
+		{"./testdata/TEST_2018-05-14_095545.jpg", 35.016953, 135.783248, 102, true},
+		{"./testdata/TEST_2019-07-21_132615_DSC_0361_DxO_PL2.jpg", 0, 0, 0, false}, // GPS IFD without position
+	}
+
+	for _, tc := range testcases {
+		f, err := os.Open(tc.filepath)
+		if err != nil {
+			t.Fatalf("%s: opening file failed, error=%s", tc.filepath, err)
+		}
+		defer f.Close()
+
+		x, err := Read(f)
+		if err != nil {
+			t.Errorf("%s: reading exifs failed, error=%s", tc.filepath, err)
+			continue
+		}
+
+		lat, lon, alt, ok := x.Location()
+		if ok != tc.ok || math.Abs(lat-tc.lat) > 1e-6 || math.Abs(lon-tc.lon) > 1e-6 || alt != tc.alt {
+			t.Errorf("%s: got=%f,%f,%f,%t want=%f,%f,%f,%t", tc.filepath, lat, lon, alt, ok, tc.lat, tc.lon, tc.alt, tc.ok)
+		}
+	}
+
+	// altitude below sea level
+	x := &Exif{Gps: GpsTags{GPSLatitude: Coordinate{Degrees: Rational{31, 1}}, GPSLongitude: Coordinate{Degrees: Rational{35, 1}},
+		GPSAltitudeRef: AltitudeBelowSeaLevel, GPSAltitude: Rational{430, 1}}}
+	if _, _, alt, _ := x.Location(); alt != -430 {
+		t.Errorf("altitude below sea level: got=%f, want=-430", alt)
+	}
+}
+
+func TestTimes(t *testing.T) {
+	paris := time.FixedZone("+02:00", 2*3600)
+	tokyo := time.FixedZone("JST", 9*3600)
+
+	testcases := []struct {
+		filepath                      string
+		loc                           *time.Location
+		original, digitized, modified time.Time
+		gps                           time.Time
+	}{
+		{"./testdata/TEST_2019-07-21_132615_DSC_0361_DxO_PL2.jpg", nil, // with offsets and fractions of seconds
+			time.Date(2019, 7, 21, 13, 26, 15, 720000000, paris),
+			time.Date(2019, 7, 21, 13, 26, 15, 720000000, paris),
+			time.Date(2019, 7, 21, 13, 26, 15, 720000000, paris),
+			time.Time{}},
+		{"./testdata/TEST_2018-05-14_095545.jpg", tokyo, // without offsets
+			time.Date(2018, 5, 14, 9, 55, 45, 0, tokyo),
+			time.Date(2002, 12, 8, 12, 0, 0, 0, tokyo),
+			time.Date(2018, 5, 14, 9, 55, 45, 0, tokyo),
+			time.Date(2018, 5, 14, 0, 55, 44, 0, time.UTC)},
+	}
+
+	for _, tc := range testcases {
+		f, err := os.Open(tc.filepath)
+		if err != nil {
+			t.Fatalf("%s: opening file failed, error=%s", tc.filepath, err)
+		}
+		defer f.Close()
+
+		x, err := Read(f)
+		if err != nil {
+			t.Errorf("%s: reading exifs failed, error=%s", tc.filepath, err)
+			continue
+		}
+
+		for _, got := range []struct {
+			name string
+			fn   func() (time.Time, bool)
+			want time.Time
+		}{
+			{"OriginalTime", func() (time.Time, bool) { return x.OriginalTime(tc.loc) }, tc.original},
+			{"DigitizedTime", func() (time.Time, bool) { return x.DigitizedTime(tc.loc) }, tc.digitized},
+			{"ModifiedTime", func() (time.Time, bool) { return x.ModifiedTime(tc.loc) }, tc.modified},
+			{"GPSTime", x.GPSTime, tc.gps},
+		} {
+			v, ok := got.fn()
+			if ok != !got.want.IsZero() || !v.Equal(got.want) {
+				t.Errorf("%s, %s: got=%s, %t, want=%s", tc.filepath, got.name, v, ok, got.want)
+			}
+		}
+	}
+
+	// missing and malformed parts
+	x := &Exif{Photo: PhotoTags{DateTimeOriginal: "    :  :     :  :  ", DateTimeDigitized: "2019:07:21 13:26:15",
+		OffsetTimeDigitized: "   :  ", SubSecTimeDigitized: "5"}}
+	if v, ok := x.OriginalTime(nil); ok {
+		t.Errorf("unknown date: got=%s, want none", v)
+	}
+	if v, _ := x.DigitizedTime(nil); !v.Equal(time.Date(2019, 7, 21, 13, 26, 15, 500000000, time.UTC)) {
+		t.Errorf("unknown offset: got=%s, want UTC time", v)
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	RegisterCatalog("de", Catalog{
+		Names:  map[string]string{"MeteringMode": "Belichtungsmessung"},
+		Values: map[string]string{"spot": "Spotmessung"},
+	})
+
+	tests := []struct {
+		lang     string
+		name     string
+		value    interface{}
+		wantName string
+		want     string
+	}{
+		{"en", "ExposureProgram", ExposureProgramManual, "ExposureProgram", "manual"},
+		{"fr", "ExposureProgram", ExposureProgramManual, "Programme d'exposition", "manuel"},
+		{"fr-CA", "GPSLatitudeRef", LatitudeNorth, "Hémisphère de la latitude", "Nord"},
+		{"ja", "MeteringMode", MeteringModeSpot, "測光方式", "スポット"},
+		{"ja-JP", "ExposureProgram", ExposureProgram(42), "露出プログラム", "ExposureProgram(42)"},
+		{"de", "MeteringMode", MeteringModeSpot, "Belichtungsmessung", "Spotmessung"},
+		{"de", "ExposureProgram", ExposureProgramManual, "ExposureProgram", "manual"},
+		{"fr", "GPSAltitude", Rational{Num: 102, Den: 1}, "Altitude", "102/1"},
+		{"fr", "DriveMode", CanonDriveMode(1), "Mode d'entraînement", "continu"},
+		{"ja", "FocusMode", CanonFocusMode(1), "フォーカスモード", "AIサーボAF"},
+	}
+
+	for _, tc := range tests {
+		p := NewPrinter(tc.lang)
+		if got := p.TagName(tc.name); got != tc.wantName {
+			t.Errorf("%s: TagName(%s) got=%s, want=%s", tc.lang, tc.name, got, tc.wantName)
+		}
+		if got := p.Sprint(tc.value); got != tc.want {
+			t.Errorf("%s: Sprint(%v) got=%s, want=%s", tc.lang, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	filepath := "./testdata/TEST_2019-07-21_132615_DSC_0361_DxO_PL2.jpg"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	tests := []struct {
+		name   string
+		want   string
+		wantFr string
+	}{
+		{"FileSource", "DSC", "appareil photo numérique"},
+		{"SceneType", "directly photographed image", "image photographiée directement"},
+		{"MeteringMode", "center-weighted average", "moyenne pondérée centrale"},
+	}
+	for _, tc := range tests {
+		tag, ok := x.Tag("Exif", tc.name)
+		if !ok {
+			t.Errorf("%s: %s not found", filepath, tc.name)
+			continue
+		}
+		def, _ := LookupTag("Exif", tag.ID)
+		if got := def.Describe(tag); got != tc.want {
+			t.Errorf("%s: %s got=%q, want=%q", filepath, tc.name, got, tc.want)
+		}
+		if got := NewPrinter("fr").Describe(def, tag); got != tc.wantFr {
+			t.Errorf("%s: %s in French got=%q, want=%q", filepath, tc.name, got, tc.wantFr)
+		}
+	}
+}
+
+func TestHas(t *testing.T) {
+	filepath := "./testdata/TEST_2019-07-21_132615_DSC_0361_DxO_PL2.jpg"
+	f, err := os.Open(filepath)
+	if err != nil {
+		t.Fatalf("%s: opening file failed, error=%s", filepath, err)
+	}
+	defer f.Close()
+
+	x, err := Read(f)
+	if err != nil {
+		t.Fatalf("%s: reading exifs failed, error=%s", filepath, err)
+	}
+
+	tests := []struct {
+		ifd, name string
+		want      bool
+	}{
+		{"IFD0", "PhotometricInterpretation", false}, // decoded as PhotometricWhiteIsZero
+		{"Exif", "ExposureMode", true},
+		{"Exif", "NoSuchTag", false},
+		{"NoSuchIFD", "ExposureMode", false},
+	}
+	for _, tc := range tests {
+		if got := x.Has(tc.ifd, tc.name); got != tc.want {
+			t.Errorf("%s: Has(%s, %s) got=%v, want=%v", filepath, tc.ifd, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	catalogs := map[string]Catalog{"fr": catalogFr, "ja": catalogJa}
+	for lang, c := range catalogs {
+		for other, o := range catalogs {
+			for name := range c.Names {
+				if _, ok := o.Names[name]; !ok {
+					t.Errorf("name %q translated in %s but not in %s", name, lang, other)
+				}
+			}
+			for value := range c.Values {
+				if _, ok := o.Values[value]; !ok {
+					t.Errorf("value %q translated in %s but not in %s", value, lang, other)
+				}
+			}
+		}
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	// 3x2 source image, each pixel recording its index
+	//   0 1 2
+	//   3 4 5
+	src := image.NewGray(image.Rect(10, 10, 13, 12))
+	for i := 0; i < 6; i++ {
+		src.SetGray(10+i%3, 10+i/3, color.Gray{Y: uint8(i)})
+	}
+
+	tests := []struct {
+		orientation Orientation
+		want        [][]uint8
+	}{
+		{OrientationTopLeft, [][]uint8{{0, 1, 2}, {3, 4, 5}}},
+		{OrientationTopRight, [][]uint8{{2, 1, 0}, {5, 4, 3}}},
+		{OrientationBottomRight, [][]uint8{{5, 4, 3}, {2, 1, 0}}},
+		{OrientationBottomLeft, [][]uint8{{3, 4, 5}, {0, 1, 2}}},
+		{OrientationLeftTop, [][]uint8{{0, 3}, {1, 4}, {2, 5}}},
+		{OrientationRightTop, [][]uint8{{3, 0}, {4, 1}, {5, 2}}},
+		{OrientationRightBottom, [][]uint8{{5, 2}, {4, 1}, {3, 0}}},
+		{OrientationLeftBottom, [][]uint8{{2, 5}, {1, 4}, {0, 3}}},
+	}
+
+	for _, tc := range tests {
+		dst := tc.orientation.Apply(src)
+		b := dst.Bounds()
+		if b.Dx() != len(tc.want[0]) || b.Dy() != len(tc.want) {
+			t.Errorf("%s: got size=%dx%d, want=%dx%d", tc.orientation, b.Dx(), b.Dy(), len(tc.want[0]), len(tc.want))
+			continue
+		}
+		for y, row := range tc.want {
+			for x, want := range row {
+				if got := color.GrayModel.Convert(dst.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y; got != want {
+					t.Errorf("%s: pixel (%d,%d) got=%d, want=%d", tc.orientation, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestTagDecoding(t *testing.T) {
+	le, be := binary.LittleEndian, binary.BigEndian
+
+	tests := []struct {
+		tag  Tag
+		got  func(Tag) interface{}
+		want interface{}
+	}{
+		{Tag{Type: TypeSByte, Count: 2, Data: []byte{0xff, 0x7f}, bo: le},
+			func(t Tag) interface{} { return t.sbyteToInt8() }, []int8{-1, 127}},
+		{Tag{Type: TypeSShort, Count: 2, Data: []byte{0xff, 0xfe, 0x00, 0x02}, bo: be},
+			func(t Tag) interface{} { return t.sshortToInt16() }, []int16{-2, 2}},
+		{Tag{Type: TypeSLong, Count: 1, Data: []byte{0xfd, 0xff, 0xff, 0xff}, bo: le},
+			func(t Tag) interface{} { return t.slongToInt32() }, []int32{-3}},
+		{Tag{Type: TypeSRational, Count: 1, Data: []byte{0xff, 0xff, 0xff, 0xfd, 0x00, 0x00, 0x00, 0x02}, bo: be},
+			func(t Tag) interface{} { return t.srationalToSRational() }, []SRational{{-3, 2}}},
+		{Tag{Type: TypeFloat, Count: 1, Data: []byte{0x00, 0x00, 0xc0, 0x3f}, bo: le},
+			func(t Tag) interface{} { return t.floatToFloat32() }, []float32{1.5}},
+		{Tag{Type: TypeDouble, Count: 1, Data: []byte{0xc0, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, bo: be},
+			func(t Tag) interface{} { return t.doubleToFloat64() }, []float64{-2.5}},
+		{Tag{Type: TypeSLong8, Count: 1, Data: []byte{0xfc, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, bo: le},
+			func(t Tag) interface{} { return t.slong8ToInt64() }, []int64{-4}},
+	}
+
+	for _, tc := range tests {
+		if got := tc.got(tc.tag); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got=%v, want=%v", tc.tag.TypeName(), got, tc.want)
+		}
+	}
+}
+
+func TestComment(t *testing.T) {
+	le, be := binary.LittleEndian, binary.BigEndian
+
+	tests := []struct {
+		data []byte
+		bo   binary.ByteOrder
+		want string
+	}{
+		{[]byte("ASCII\x00\x00\x00Pink Panther   "), be, "Pink Panther"},
+		{[]byte("\x00\x00\x00\x00\x00\x00\x00\x00Pink Panther\x00\x00"), be, "Pink Panther"},
+		{[]byte("UNICODE\x00\x00\xe9\x00t\x00\xe9"), be, "été"},
+		{[]byte("UNICODE\x00\xe9\x00t\x00\xe9\x00"), le, "été"},
+		{[]byte("UNICODE\x00\xff\xfe\xe9\x00t\x00\xe9\x00"), be, "été"}, // byte order mark
+		{[]byte("JIS\x00\x00\x00\x00\x00\x46\x7c\x4b\x5c  "), be, "日本"},
+		{[]byte("JIS\x00\x00\x00\x00\x00\x1b$B\x46\x7c\x4b\x5c\x1b(BGPS"), be, "日本GPS"},
+		{[]byte("GPS"), be, "GPS"}, // no character code
+	}
+
+	for _, id := range []struct {
+		ifd string
+		id  uint16
+	}{{"Exif", 37510}, {"GPS", 27}, {"GPS", 28}} {
+		def, _ := LookupTag(id.ifd, id.id)
+		for _, tc := range tests {
+			tag := Tag{ID: id.id, Type: TypeUndefined, Count: uint64(len(tc.data)), Data: tc.data, bo: tc.bo}
+			if got := def.Describe(tag); got != tc.want {
+				t.Errorf("%s %q: got=%q, want=%q", def.Name, tc.data, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestRational(t *testing.T) {
+	tests := []struct {
+		r       fmt.Stringer
+		float   float64
+		reduced fmt.Stringer
+	}{
+		{Rational{1, 250}, 0.004, Rational{1, 250}},
+		{Rational{4845, 1918}, 4845.0 / 1918.0, Rational{4845, 1918}},
+		{Rational{300, 100}, 3, Rational{3, 1}},
+		{Rational{1, 0}, 0, Rational{1, 0}},
+		{SRational{-10, 4}, -2.5, SRational{-5, 2}},
+		{SRational{10, -4}, -2.5, SRational{-5, 2}},
+		{SRational{0, 1}, 0, SRational{0, 1}},
+	}
+
+	for _, tc := range tests {
+		var (
+			float   float64
+			reduced fmt.Stringer
+		)
+		switch r := tc.r.(type) {
+		case Rational:
+			float, reduced = r.Float64(), r.Reduce()
+		case SRational:
+			float, reduced = r.Float64(), r.Reduce()
+		}
+		if float != tc.float || reduced != tc.reduced {
+			t.Errorf("%s: got=(%f, %s), want=(%f, %s)", tc.r, float, reduced, tc.float, tc.reduced)
+		}
+	}
+}
+
 func testEachFields(t *testing.T, filepath string, theType reflect.Type, got reflect.Value, want reflect.Value) {
 	for i := 0; i < theType.NumField(); i++ {
 		field := theType.Field(i)
@@ -108,7 +1366,7 @@ func testEachFields(t *testing.T, filepath string, theType reflect.Type, got ref
 		wantV := want.FieldByName(field.Name)
 
 		switch gotV.Kind() {
-		case reflect.Float64:
+		case reflect.Float32, reflect.Float64:
 			if gotV.Float() != wantV.Float() {
 				t.Errorf("%s, %s.%s: got=%f, want=%f", filepath, theType.Name(), field.Name, gotV.Float(), wantV.Float())
 			}
@@ -116,10 +1374,14 @@ func testEachFields(t *testing.T, filepath string, theType reflect.Type, got ref
 			if gotV.String() != wantV.String() {
 				t.Errorf("%s, %s.%s: got=%s, want=%s", filepath, theType.Name(), field.Name, gotV.String(), wantV.String())
 			}
-		case reflect.Uint16:
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32:
 			if gotV.Uint() != wantV.Uint() {
 				t.Errorf("%s, %s.%s: got=%d, want=%d", filepath, theType.Name(), field.Name, gotV.Uint(), wantV.Uint())
 			}
+		case reflect.Struct:
+			if !reflect.DeepEqual(gotV.Interface(), wantV.Interface()) {
+				t.Errorf("%s, %s.%s: got=%v, want=%v", filepath, theType.Name(), field.Name, gotV.Interface(), wantV.Interface())
+			}
 		}
 	}
 }
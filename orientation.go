@@ -0,0 +1,67 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import "image"
+
+// Orientation is the orientation of the image in terms of rows and columns.
+// It describes where the 0th row and the 0th column of the recorded image are when the image is viewed upright.
+type Orientation uint16
+
+// Orientations defined by Exif 2.31, named after the position of the 0th row and the 0th column
+const (
+	OrientationTopLeft     Orientation = 1 // normal
+	OrientationTopRight    Orientation = 2 // mirrored horizontally
+	OrientationBottomRight Orientation = 3 // rotated 180°
+	OrientationBottomLeft  Orientation = 4 // mirrored vertically
+	OrientationLeftTop     Orientation = 5 // mirrored horizontally and rotated 270° clockwise
+	OrientationRightTop    Orientation = 6 // rotated 90° clockwise
+	OrientationRightBottom Orientation = 7 // mirrored horizontally and rotated 90° clockwise
+	OrientationLeftBottom  Orientation = 8 // rotated 270° clockwise
+)
+
+func (v Orientation) String() string {
+	return describeValue("IFD0", 274, "Orientation", uint16(v))
+}
+
+// Apply returns img transformed to be viewed upright, by rotating and flipping it as described by the orientation.
+// The transformed image is a new *image.RGBA, except for the normal orientation or an unknown one, for which
+// img is returned unchanged.
+func (v Orientation) Apply(img image.Image) image.Image {
+	if v < OrientationTopRight || v > OrientationLeftBottom {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if v >= OrientationLeftTop { // rows and columns are swapped
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch v {
+			case OrientationTopRight:
+				sx, sy = w-1-x, y
+			case OrientationBottomRight:
+				sx, sy = w-1-x, h-1-y
+			case OrientationBottomLeft:
+				sx, sy = x, h-1-y
+			case OrientationLeftTop:
+				sx, sy = y, x
+			case OrientationRightTop:
+				sx, sy = y, h-1-x
+			case OrientationRightBottom:
+				sx, sy = w-1-y, h-1-x
+			case OrientationLeftBottom:
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
@@ -3,7 +3,10 @@
 
 package nifuda
 
-import "encoding/binary"
+import (
+	"fmt"
+	"strings"
+)
 
 // PhotoTags contains tags from Exif SubIFD.
 // Fields are defined in order they appeared in chapter 4.6.5 of Exif 2.31
@@ -12,9 +15,18 @@ type PhotoTags struct {
 	ExifVersion     string
 	FlashpixVersion string
 	// B. Tag Relating to Image Data Characteristics
+	ColorSpace ColorSpace
+	Gamma      Rational
 	// C. Tags Relating to Image Configuration
+	ComponentsConfiguration ComponentsConfiguration
+	CompressedBitsPerPixel  Rational
+	PixelXDimension         uint32 // recorded as SHORT or LONG
+	PixelYDimension         uint32 // recorded as SHORT or LONG
 	// D. Tags Relating to User Information
+	MakerNote   []byte // raw content, format is manufacturer specific
+	UserComment string
 	// E. Tag Relating to Related File Information
+	RelatedSoundFile string
 	// F. Tags Relating to Date and Time
 	DateTimeOriginal    string
 	DateTimeDigitized   string
@@ -25,84 +37,720 @@ type PhotoTags struct {
 	SubSecTimeOriginal  string
 	SubSecTimeDigitized string
 	// G. Tags Relating to Picture-Taking Conditions
-	ExposureProgram     string
-	SpectralSensitivity string
-	MeteringMode        string
-}
-
-func parseIFDTagsAsPhotoTags(ifd *ifd, bo binary.ByteOrder) PhotoTags {
-	var t PhotoTags
-
-	for _, ifdtag := range ifd.tags {
-		switch ifdtag.id {
-		case 36864: // ExifVersion
-			t.ExifVersion = ifdtag.undefinedToString()
-		case 40960: // FlashpixVersion
-			t.FlashpixVersion = ifdtag.undefinedToString()
-		case 36867: // DateTimeOriginal
-			t.DateTimeOriginal = ifdtag.asciiToString()
-		case 36868: // DateTimeDigitized
-			t.DateTimeDigitized = ifdtag.asciiToString()
-		case 36880: // OffsetTime
-			t.OffsetTime = ifdtag.asciiToString()
-		case 36881: // OffsetTimeOriginal
-			t.OffsetTimeOriginal = ifdtag.asciiToString()
-		case 36882: // OffsetTimeDigitized
-			t.OffsetTimeDigitized = ifdtag.asciiToString()
-		case 37520: // SubsecTime
-			t.SubSecTime = ifdtag.asciiToString()
-		case 37521: // SubsecTimeOriginal
-			t.SubSecTimeOriginal = ifdtag.asciiToString()
-		case 37522: // SubSecTimeDigitized
-			t.SubSecTimeDigitized = ifdtag.asciiToString()
-		case 34850: // ExposureProgram
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 0:
-				t.ExposureProgram = "not defined"
-			case 1:
-				t.ExposureProgram = "manual"
-			case 2:
-				t.ExposureProgram = "normal program"
-			case 3:
-				t.ExposureProgram = "aperture priority"
-			case 4:
-				t.ExposureProgram = "shutter priority"
-			case 5:
-				t.ExposureProgram = "creative program"
-			case 6:
-				t.ExposureProgram = "action program"
-			case 7:
-				t.ExposureProgram = "portrait mode"
-			case 8:
-				t.ExposureProgram = "landscape mode"
-			}
-		case 34852: // SpectralSensitivity
-			t.SpectralSensitivity = ifdtag.asciiToString()
-		case 34855: // PhotographicSensitivity (ISO 12232)
-		case 34856: // OECF (ISO 14524)
-		case 34864: // SensitivityType (ISO 12232)
-		case 34865: // StandardOutputSensitivity (ISO 12232)
-		case 37383: // MeteringMode
-			switch ifdtag.shortToUint16(bo)[0] {
-			case 0:
-				t.MeteringMode = "unknown"
-			case 1:
-				t.MeteringMode = "average"
-			case 2:
-				t.MeteringMode = "center-weighted average"
-			case 3:
-				t.MeteringMode = "spot"
-			case 4:
-				t.MeteringMode = "multispot"
-			case 5:
-				t.MeteringMode = "pattern"
-			case 6:
-				t.MeteringMode = "partial"
-			case 255:
-				t.MeteringMode = "other"
-			}
+	ExposureTime              Rational
+	FNumber                   Rational
+	ExposureProgram           ExposureProgram
+	SpectralSensitivity       string
+	PhotographicSensitivity   uint16
+	OECF                      []byte
+	SensitivityType           SensitivityType
+	StandardOutputSensitivity uint32
+	RecommendedExposureIndex  uint32
+	ISOSpeed                  uint32
+	ISOSpeedLatitudeyyy       uint32
+	ISOSpeedLatitudezzz       uint32
+	ShutterSpeedValue         SRational
+	ApertureValue             Rational
+	BrightnessValue           SRational
+	ExposureBiasValue         SRational
+	MaxApertureValue          Rational
+	SubjectDistance           Rational
+	MeteringMode              MeteringMode
+	LightSource               LightSource
+	Flash                     Flash
+	FocalLength               Rational
+	SubjectArea               []uint16
+	FlashEnergy               Rational
+	SpatialFrequencyResponse  []byte
+	FocalPlaneXResolution     Rational
+	FocalPlaneYResolution     Rational
+	FocalPlaneResolutionUnit  ResolutionUnit
+	SubjectLocation           []uint16
+	ExposureIndex             Rational
+	SensingMethod             SensingMethod
+	FileSource                FileSource
+	SceneType                 SceneType
+	CFAPattern                []byte
+	CustomRendered            CustomRendered
+	ExposureMode              ExposureMode
+	WhiteBalance              WhiteBalance
+	DigitalZoomRatio          Rational
+	FocalLengthIn35mmFilm     uint16
+	SceneCaptureType          SceneCaptureType
+	GainControl               GainControl
+	Contrast                  Contrast
+	Saturation                Saturation
+	Sharpness                 Sharpness
+	DeviceSettingDescription  []byte
+	SubjectDistanceRange      SubjectDistanceRange
+	// H. Other Tags
+	ImageUniqueID     string
+	CameraOwnerName   string
+	BodySerialNumber  string
+	LensSpecification []Rational // minimum and maximum focal lengths, minimum F numbers at these focal lengths
+	LensMake          string
+	LensModel         string
+	LensSerialNumber  string
+}
+
+// ColorSpace is the color space of the image.
+type ColorSpace uint16
+
+// Color spaces defined by Exif 2.31
+const (
+	ColorSpaceSRGB         ColorSpace = 1
+	ColorSpaceUncalibrated ColorSpace = 0xFFFF
+)
+
+func (v ColorSpace) String() string {
+	return describeValue("Exif", 40961, "ColorSpace", uint16(v))
+}
+
+// ComponentsConfiguration is the order of the channels of each pixel, for compressed data.
+type ComponentsConfiguration []byte
+
+var componentNames = []string{"-", "Y", "Cb", "Cr", "R", "G", "B"}
+
+// String returns the channels separated by commas, like "Y, Cb, Cr, -" for YCbCr data.
+func (v ComponentsConfiguration) String() string {
+	names := make([]string, len(v))
+	for i, c := range v {
+		if int(c) < len(componentNames) {
+			names[i] = componentNames[c]
+		} else {
+			names[i] = fmt.Sprintf("%d", c)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// ExposureProgram is the class of the program used by the camera to set exposure.
+type ExposureProgram uint16
+
+// Exposure programs defined by Exif 2.31
+const (
+	ExposureProgramNotDefined       ExposureProgram = 0
+	ExposureProgramManual           ExposureProgram = 1
+	ExposureProgramNormal           ExposureProgram = 2
+	ExposureProgramAperturePriority ExposureProgram = 3
+	ExposureProgramShutterPriority  ExposureProgram = 4
+	ExposureProgramCreative         ExposureProgram = 5
+	ExposureProgramAction           ExposureProgram = 6
+	ExposureProgramPortrait         ExposureProgram = 7
+	ExposureProgramLandscape        ExposureProgram = 8
+)
+
+func (v ExposureProgram) String() string {
+	return describeValue("Exif", 34850, "ExposureProgram", uint16(v))
+}
+
+// MeteringMode is the metering mode used by the camera.
+type MeteringMode uint16
+
+// Metering modes defined by Exif 2.31
+const (
+	MeteringModeUnknown               MeteringMode = 0
+	MeteringModeAverage               MeteringMode = 1
+	MeteringModeCenterWeightedAverage MeteringMode = 2
+	MeteringModeSpot                  MeteringMode = 3
+	MeteringModeMultiSpot             MeteringMode = 4
+	MeteringModePattern               MeteringMode = 5
+	MeteringModePartial               MeteringMode = 6
+	MeteringModeOther                 MeteringMode = 255
+)
+
+func (v MeteringMode) String() string {
+	return describeValue("Exif", 37383, "MeteringMode", uint16(v))
+}
+
+// ISO returns the sensitivity of the camera.
+// When PhotographicSensitivity is saturated at 65535, the sensitivity is taken from the tag designated by SensitivityType.
+func (t PhotoTags) ISO() uint32 {
+	if t.PhotographicSensitivity < 65535 {
+		return uint32(t.PhotographicSensitivity)
+	}
+	switch t.SensitivityType {
+	case SensitivityTypeSOS, SensitivityTypeSOSAndREI, SensitivityTypeSOSAndISOSpeed, SensitivityTypeSOSAndREIAndISOSpeed:
+		if t.StandardOutputSensitivity > 0 {
+			return t.StandardOutputSensitivity
 		}
+	case SensitivityTypeREI, SensitivityTypeREIAndISOSpeed:
+		if t.RecommendedExposureIndex > 0 {
+			return t.RecommendedExposureIndex
+		}
+	}
+	if t.ISOSpeed > 0 {
+		return t.ISOSpeed
 	}
+	return uint32(t.PhotographicSensitivity)
+}
+
+// SensitivityType indicates which ISO 12232 parameter is recorded by PhotographicSensitivity.
+type SensitivityType uint16
+
+// Sensitivity types defined by Exif 2.31
+const (
+	SensitivityTypeUnknown              SensitivityType = 0
+	SensitivityTypeSOS                  SensitivityType = 1
+	SensitivityTypeREI                  SensitivityType = 2
+	SensitivityTypeISOSpeed             SensitivityType = 3
+	SensitivityTypeSOSAndREI            SensitivityType = 4
+	SensitivityTypeSOSAndISOSpeed       SensitivityType = 5
+	SensitivityTypeREIAndISOSpeed       SensitivityType = 6
+	SensitivityTypeSOSAndREIAndISOSpeed SensitivityType = 7
+)
+
+func (v SensitivityType) String() string {
+	return describeValue("Exif", 34864, "SensitivityType", uint16(v))
+}
+
+// LightSource is the kind of light source.
+type LightSource uint16
+
+// Light sources defined by Exif 2.31
+const (
+	LightSourceUnknown              LightSource = 0
+	LightSourceDaylight             LightSource = 1
+	LightSourceFluorescent          LightSource = 2
+	LightSourceTungsten             LightSource = 3
+	LightSourceFlash                LightSource = 4
+	LightSourceFineWeather          LightSource = 9
+	LightSourceCloudyWeather        LightSource = 10
+	LightSourceShade                LightSource = 11
+	LightSourceDaylightFluorescent  LightSource = 12
+	LightSourceDayWhiteFluorescent  LightSource = 13
+	LightSourceCoolWhiteFluorescent LightSource = 14
+	LightSourceWhiteFluorescent     LightSource = 15
+	LightSourceWarmWhiteFluorescent LightSource = 16
+	LightSourceStandardLightA       LightSource = 17
+	LightSourceStandardLightB       LightSource = 18
+	LightSourceStandardLightC       LightSource = 19
+	LightSourceD55                  LightSource = 20
+	LightSourceD65                  LightSource = 21
+	LightSourceD75                  LightSource = 22
+	LightSourceD50                  LightSource = 23
+	LightSourceISOStudioTungsten    LightSource = 24
+	LightSourceOther                LightSource = 255
+)
+
+func (v LightSource) String() string {
+	return describeValue("Exif", 37384, "LightSource", uint16(v))
+}
+
+// Flash is the status of the flash when the image was shot, recorded as a set of bit fields.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type Flash uint16
+
+// Fired reports whether the flash fired.
+func (v Flash) Fired() bool {
+	return v&0x01 != 0
+}
+
+// RedEyeReduction reports whether the red-eye reduction mode was used.
+func (v Flash) RedEyeReduction() bool {
+	return v&0x40 != 0
+}
+
+func (v Flash) String() string {
+	return describeValue("Exif", 37385, "Flash", uint16(v))
+}
+
+// SensingMethod is the type of image sensor.
+type SensingMethod uint16
+
+// Sensing methods defined by Exif 2.31
+const (
+	SensingMethodNotDefined            SensingMethod = 1
+	SensingMethodOneChipColorArea      SensingMethod = 2
+	SensingMethodTwoChipColorArea      SensingMethod = 3
+	SensingMethodThreeChipColorArea    SensingMethod = 4
+	SensingMethodColorSequentialArea   SensingMethod = 5
+	SensingMethodTrilinear             SensingMethod = 7
+	SensingMethodColorSequentialLinear SensingMethod = 8
+)
+
+func (v SensingMethod) String() string {
+	return describeValue("Exif", 41495, "SensingMethod", uint16(v))
+}
+
+// FileSource is the kind of device which created the image.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type FileSource uint8
+
+// File sources defined by Exif 2.31
+const (
+	FileSourceOthers              FileSource = 0
+	FileSourceTransparencyScanner FileSource = 1
+	FileSourceReflexScanner       FileSource = 2
+	FileSourceDigitalStillCamera  FileSource = 3
+)
+
+func (v FileSource) String() string {
+	return describeValue("Exif", 41728, "FileSource", uint8(v))
+}
 
-	return t
+// SceneType is the type of scene.
+type SceneType uint8
+
+// Scene types defined by Exif 2.31
+const (
+	SceneTypeDirectlyPhotographed SceneType = 1
+)
+
+func (v SceneType) String() string {
+	return describeValue("Exif", 41729, "SceneType", uint8(v))
+}
+
+// CustomRendered indicates whether a special processing was applied to the image data.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type CustomRendered uint16
+
+// Renderings defined by Exif 2.31
+const (
+	CustomRenderedNormal CustomRendered = 0
+	CustomRenderedCustom CustomRendered = 1
+)
+
+func (v CustomRendered) String() string {
+	return describeValue("Exif", 41985, "CustomRendered", uint16(v))
+}
+
+// ExposureMode is the exposure mode set when the image was shot.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type ExposureMode uint16
+
+// Exposure modes defined by Exif 2.31
+const (
+	ExposureModeAuto        ExposureMode = 0
+	ExposureModeManual      ExposureMode = 1
+	ExposureModeAutoBracket ExposureMode = 2
+)
+
+func (v ExposureMode) String() string {
+	return describeValue("Exif", 41986, "ExposureMode", uint16(v))
+}
+
+// WhiteBalance is the white balance mode set when the image was shot.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type WhiteBalance uint16
+
+// White balance modes defined by Exif 2.31
+const (
+	WhiteBalanceAuto   WhiteBalance = 0
+	WhiteBalanceManual WhiteBalance = 1
+)
+
+func (v WhiteBalance) String() string {
+	return describeValue("Exif", 41987, "WhiteBalance", uint16(v))
+}
+
+// SceneCaptureType is the type of scene that was shot.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type SceneCaptureType uint16
+
+// Scene capture types defined by Exif 2.31
+const (
+	SceneCaptureTypeStandard  SceneCaptureType = 0
+	SceneCaptureTypeLandscape SceneCaptureType = 1
+	SceneCaptureTypePortrait  SceneCaptureType = 2
+	SceneCaptureTypeNight     SceneCaptureType = 3
+)
+
+func (v SceneCaptureType) String() string {
+	return describeValue("Exif", 41990, "SceneCaptureType", uint16(v))
+}
+
+// GainControl is the degree of overall image gain adjustment.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type GainControl uint16
+
+// Gain controls defined by Exif 2.31
+const (
+	GainControlNone         GainControl = 0
+	GainControlLowGainUp    GainControl = 1
+	GainControlHighGainUp   GainControl = 2
+	GainControlLowGainDown  GainControl = 3
+	GainControlHighGainDown GainControl = 4
+)
+
+func (v GainControl) String() string {
+	return describeValue("Exif", 41991, "GainControl", uint16(v))
+}
+
+// Contrast is the direction of contrast processing applied by the camera.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type Contrast uint16
+
+// Contrast processings defined by Exif 2.31
+const (
+	ContrastNormal Contrast = 0
+	ContrastSoft   Contrast = 1
+	ContrastHard   Contrast = 2
+)
+
+func (v Contrast) String() string {
+	return describeValue("Exif", 41992, "Contrast", uint16(v))
+}
+
+// Saturation is the direction of saturation processing applied by the camera.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type Saturation uint16
+
+// Saturation processings defined by Exif 2.31
+const (
+	SaturationNormal Saturation = 0
+	SaturationLow    Saturation = 1
+	SaturationHigh   Saturation = 2
+)
+
+func (v Saturation) String() string {
+	return describeValue("Exif", 41993, "Saturation", uint16(v))
+}
+
+// Sharpness is the direction of sharpness processing applied by the camera.
+// As 0 is a valid value, use Exif.Has to know whether the tag is recorded.
+type Sharpness uint16
+
+// Sharpness processings defined by Exif 2.31
+const (
+	SharpnessNormal Sharpness = 0
+	SharpnessSoft   Sharpness = 1
+	SharpnessHard   Sharpness = 2
+)
+
+func (v Sharpness) String() string {
+	return describeValue("Exif", 41994, "Sharpness", uint16(v))
+}
+
+// SubjectDistanceRange is the distance to the subject.
+type SubjectDistanceRange uint16
+
+// Subject distance ranges defined by Exif 2.31
+const (
+	SubjectDistanceRangeUnknown SubjectDistanceRange = 0
+	SubjectDistanceRangeMacro   SubjectDistanceRange = 1
+	SubjectDistanceRangeClose   SubjectDistanceRange = 2
+	SubjectDistanceRangeDistant SubjectDistanceRange = 3
+)
+
+func (v SubjectDistanceRange) String() string {
+	return describeValue("Exif", 41996, "SubjectDistanceRange", uint16(v))
+}
+
+var photoTagDefs = []TagDef{
+	// A. Tags Relating to Version
+	{ID: 36864, IFD: "Exif", Name: "ExifVersion", Types: []uint16{TypeUndefined}, Count: 4,
+		Description: "Version of the Exif standard supported"},
+	{ID: 40960, IFD: "Exif", Name: "FlashpixVersion", Types: []uint16{TypeUndefined}, Count: 4,
+		Description: "Version of the Flashpix format supported"},
+	// B. Tag Relating to Image Data Characteristics
+	{ID: 40961, IFD: "Exif", Name: "ColorSpace", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"1":     "sRGB",
+			"65535": "uncalibrated",
+		},
+		Description: "Color space of the image"},
+	{ID: 42240, IFD: "Exif", Name: "Gamma", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Gamma coefficient of the transfer function"},
+	// C. Tags Relating to Image Configuration
+	{ID: 37121, IFD: "Exif", Name: "ComponentsConfiguration", Types: []uint16{TypeUndefined}, Count: 4,
+		Description: "Order of the channels of each pixel, for compressed data",
+		format:      func(t Tag) string { return ComponentsConfiguration(t.Data).String() }},
+	{ID: 37122, IFD: "Exif", Name: "CompressedBitsPerPixel", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Compression ratio of the image, in bits per pixel"},
+	{ID: 40962, IFD: "Exif", Name: "PixelXDimension", Types: []uint16{TypeShort, TypeLong}, Count: 1,
+		Description: "Width of the meaningful image, when compressed"},
+	{ID: 40963, IFD: "Exif", Name: "PixelYDimension", Types: []uint16{TypeShort, TypeLong}, Count: 1,
+		Description: "Height of the meaningful image, when compressed"},
+	// D. Tags Relating to User Information
+	{ID: 37500, IFD: "Exif", Name: "MakerNote", Types: []uint16{TypeUndefined},
+		Description: "Information recorded by the manufacturer, in a format of its own"},
+	{ID: 37510, IFD: "Exif", Name: "UserComment", Types: []uint16{TypeUndefined},
+		Description: "Comment written by the user, prefixed by its character code",
+		format:      formatComment},
+	// E. Tag Relating to Related File Information
+	{ID: 40964, IFD: "Exif", Name: "RelatedSoundFile", Types: []uint16{TypeASCII}, Count: 13,
+		Description: "Name of an audio file related to the image"},
+	// F. Tags Relating to Date and Time
+	{ID: 36867, IFD: "Exif", Name: "DateTimeOriginal", Types: []uint16{TypeASCII},
+		Description: "Date and time when the original image data was generated"},
+	{ID: 36868, IFD: "Exif", Name: "DateTimeDigitized", Types: []uint16{TypeASCII},
+		Description: "Date and time when the image was stored as digital data"},
+	{ID: 36880, IFD: "Exif", Name: "OffsetTime", Types: []uint16{TypeASCII},
+		Description: "Offset from UTC of DateTime"},
+	{ID: 36881, IFD: "Exif", Name: "OffsetTimeOriginal", Types: []uint16{TypeASCII},
+		Description: "Offset from UTC of DateTimeOriginal"},
+	{ID: 36882, IFD: "Exif", Name: "OffsetTimeDigitized", Types: []uint16{TypeASCII},
+		Description: "Offset from UTC of DateTimeDigitized"},
+	{ID: 37520, IFD: "Exif", Name: "SubSecTime", Types: []uint16{TypeASCII},
+		Description: "Fractions of seconds of DateTime"},
+	{ID: 37521, IFD: "Exif", Name: "SubSecTimeOriginal", Types: []uint16{TypeASCII},
+		Description: "Fractions of seconds of DateTimeOriginal"},
+	{ID: 37522, IFD: "Exif", Name: "SubSecTimeDigitized", Types: []uint16{TypeASCII},
+		Description: "Fractions of seconds of DateTimeDigitized"},
+	// G. Tags Relating to Picture-Taking Conditions
+	{ID: 33434, IFD: "Exif", Name: "ExposureTime", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Exposure time, in seconds"},
+	{ID: 33437, IFD: "Exif", Name: "FNumber", Types: []uint16{TypeRational}, Count: 1,
+		Description: "F number"},
+	{ID: 34850, IFD: "Exif", Name: "ExposureProgram", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "not defined",
+			"1": "manual",
+			"2": "normal program",
+			"3": "aperture priority",
+			"4": "shutter priority",
+			"5": "creative program",
+			"6": "action program",
+			"7": "portrait mode",
+			"8": "landscape mode",
+		},
+		Description: "Class of the program used by the camera to set exposure"},
+	{ID: 34852, IFD: "Exif", Name: "SpectralSensitivity", Types: []uint16{TypeASCII},
+		Description: "Spectral sensitivity of each channel"},
+	{ID: 34855, IFD: "Exif", Name: "PhotographicSensitivity", Types: []uint16{TypeShort},
+		Description: "Sensitivity of the camera, as defined by ISO 12232"},
+	{ID: 34856, IFD: "Exif", Name: "OECF", Types: []uint16{TypeUndefined},
+		Description: "Opto-Electric Conversion Function, as defined by ISO 14524"},
+	{ID: 34864, IFD: "Exif", Name: "SensitivityType", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "unknown",
+			"1": "standard output sensitivity",
+			"2": "recommended exposure index",
+			"3": "ISO speed",
+			"4": "standard output sensitivity and recommended exposure index",
+			"5": "standard output sensitivity and ISO speed",
+			"6": "recommended exposure index and ISO speed",
+			"7": "standard output sensitivity, recommended exposure index and ISO speed",
+		},
+		Description: "Which ISO 12232 parameter is recorded by PhotographicSensitivity"},
+	{ID: 34865, IFD: "Exif", Name: "StandardOutputSensitivity", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Standard output sensitivity, as defined by ISO 12232"},
+	{ID: 34866, IFD: "Exif", Name: "RecommendedExposureIndex", Types: []uint16{TypeLong}, Count: 1,
+		Description: "Recommended exposure index, as defined by ISO 12232"},
+	{ID: 34867, IFD: "Exif", Name: "ISOSpeed", Types: []uint16{TypeLong}, Count: 1,
+		Description: "ISO speed, as defined by ISO 12232"},
+	{ID: 34868, IFD: "Exif", Name: "ISOSpeedLatitudeyyy", Types: []uint16{TypeLong}, Count: 1,
+		Description: "ISO speed latitude yyy, as defined by ISO 12232"},
+	{ID: 34869, IFD: "Exif", Name: "ISOSpeedLatitudezzz", Types: []uint16{TypeLong}, Count: 1,
+		Description: "ISO speed latitude zzz, as defined by ISO 12232"},
+	{ID: 37377, IFD: "Exif", Name: "ShutterSpeedValue", Types: []uint16{TypeSRational}, Count: 1,
+		Description: "Shutter speed, in APEX units"},
+	{ID: 37378, IFD: "Exif", Name: "ApertureValue", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Lens aperture, in APEX units"},
+	{ID: 37379, IFD: "Exif", Name: "BrightnessValue", Types: []uint16{TypeSRational}, Count: 1,
+		Description: "Brightness value, in APEX units"},
+	{ID: 37380, IFD: "Exif", Name: "ExposureBiasValue", Types: []uint16{TypeSRational}, Count: 1,
+		Description: "Exposure bias, in APEX units"},
+	{ID: 37381, IFD: "Exif", Name: "MaxApertureValue", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Smallest F number of the lens, in APEX units"},
+	{ID: 37382, IFD: "Exif", Name: "SubjectDistance", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Distance to the subject, in meters"},
+	{ID: 37383, IFD: "Exif", Name: "MeteringMode", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0":   "unknown",
+			"1":   "average",
+			"2":   "center-weighted average",
+			"3":   "spot",
+			"4":   "multispot",
+			"5":   "pattern",
+			"6":   "partial",
+			"255": "other",
+		},
+		Description: "Metering mode"},
+	{ID: 37384, IFD: "Exif", Name: "LightSource", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0":   "unknown",
+			"1":   "daylight",
+			"2":   "fluorescent",
+			"3":   "tungsten (incandescent light)",
+			"4":   "flash",
+			"9":   "fine weather",
+			"10":  "cloudy weather",
+			"11":  "shade",
+			"12":  "daylight fluorescent (D 5700 - 7100K)",
+			"13":  "day white fluorescent (N 4600 - 5500K)",
+			"14":  "cool white fluorescent (W 3800 - 4500K)",
+			"15":  "white fluorescent (WW 3250 - 3800K)",
+			"16":  "warm white fluorescent (L 2600 - 3250K)",
+			"17":  "standard light A",
+			"18":  "standard light B",
+			"19":  "standard light C",
+			"20":  "D55",
+			"21":  "D65",
+			"22":  "D75",
+			"23":  "D50",
+			"24":  "ISO studio tungsten",
+			"255": "other light source",
+		},
+		Description: "Kind of light source"},
+	{ID: 37385, IFD: "Exif", Name: "Flash", Types: []uint16{TypeShort}, Count: 1,
+		// combinations of bit fields listed by Exif 2.31
+		Values: map[string]string{
+			"0":  "flash did not fire",
+			"1":  "flash fired",
+			"5":  "flash fired, return light not detected",
+			"7":  "flash fired, return light detected",
+			"8":  "flash did not fire, compulsory flash mode",
+			"9":  "flash fired, compulsory flash mode",
+			"13": "flash fired, compulsory flash mode, return light not detected",
+			"15": "flash fired, compulsory flash mode, return light detected",
+			"16": "flash did not fire, compulsory flash mode",
+			"20": "flash did not fire, return light not detected",
+			"24": "flash did not fire, auto mode",
+			"25": "flash fired, auto mode",
+			"29": "flash fired, auto mode, return light not detected",
+			"31": "flash fired, auto mode, return light detected",
+			"32": "no flash function",
+			"48": "flash did not fire, no flash function",
+			"65": "flash fired, red-eye reduction mode",
+			"69": "flash fired, red-eye reduction mode, return light not detected",
+			"71": "flash fired, red-eye reduction mode, return light detected",
+			"73": "flash fired, compulsory flash mode, red-eye reduction mode",
+			"77": "flash fired, compulsory flash mode, red-eye reduction mode, return light not detected",
+			"79": "flash fired, compulsory flash mode, red-eye reduction mode, return light detected",
+			"80": "flash did not fire, red-eye reduction mode",
+			"88": "flash did not fire, auto mode, red-eye reduction mode",
+			"89": "flash fired, auto mode, red-eye reduction mode",
+			"93": "flash fired, auto mode, return light not detected, red-eye reduction mode",
+			"95": "flash fired, auto mode, return light detected, red-eye reduction mode",
+		},
+		Description: "Status of the flash"},
+	{ID: 37386, IFD: "Exif", Name: "FocalLength", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Actual focal length of the lens, in millimeters"},
+	{ID: 37396, IFD: "Exif", Name: "SubjectArea", Types: []uint16{TypeShort},
+		Description: "Location and area of the main subject"},
+	{ID: 41483, IFD: "Exif", Name: "FlashEnergy", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Strobe energy, in BCPS"},
+	{ID: 41484, IFD: "Exif", Name: "SpatialFrequencyResponse", Types: []uint16{TypeUndefined},
+		Description: "Spatial frequency table, as defined by ISO 12233"},
+	{ID: 41486, IFD: "Exif", Name: "FocalPlaneXResolution", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Number of pixels in the image width per FocalPlaneResolutionUnit on the camera focal plane"},
+	{ID: 41487, IFD: "Exif", Name: "FocalPlaneYResolution", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Number of pixels in the image height per FocalPlaneResolutionUnit on the camera focal plane"},
+	{ID: 41488, IFD: "Exif", Name: "FocalPlaneResolutionUnit", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"2": "inches",
+			"3": "centimeters",
+		},
+		Description: "Unit for measuring FocalPlaneXResolution and FocalPlaneYResolution"},
+	{ID: 41492, IFD: "Exif", Name: "SubjectLocation", Types: []uint16{TypeShort}, Count: 2,
+		Description: "Location of the main subject"},
+	{ID: 41493, IFD: "Exif", Name: "ExposureIndex", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Exposure index selected on the camera"},
+	{ID: 41495, IFD: "Exif", Name: "SensingMethod", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"1": "not defined",
+			"2": "one-chip color area sensor",
+			"3": "two-chip color area sensor",
+			"4": "three-chip color area sensor",
+			"5": "color sequential area sensor",
+			"7": "trilinear sensor",
+			"8": "color sequential linear sensor",
+		},
+		Description: "Type of image sensor"},
+	{ID: 41728, IFD: "Exif", Name: "FileSource", Types: []uint16{TypeUndefined}, Count: 1,
+		Values: map[string]string{
+			"0": "others",
+			"1": "scanner of transparent type",
+			"2": "scanner of reflex type",
+			"3": "DSC",
+		},
+		Description: "Kind of device which created the image"},
+	{ID: 41729, IFD: "Exif", Name: "SceneType", Types: []uint16{TypeUndefined}, Count: 1,
+		Values: map[string]string{
+			"1": "directly photographed image",
+		},
+		Description: "Type of scene"},
+	{ID: 41730, IFD: "Exif", Name: "CFAPattern", Types: []uint16{TypeUndefined},
+		Description: "Color filter array geometric pattern of the image sensor"},
+	{ID: 41985, IFD: "Exif", Name: "CustomRendered", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "normal process",
+			"1": "custom process",
+		},
+		Description: "Whether a special processing was applied to the image data"},
+	{ID: 41986, IFD: "Exif", Name: "ExposureMode", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "auto exposure",
+			"1": "manual exposure",
+			"2": "auto bracket",
+		},
+		Description: "Exposure mode"},
+	{ID: 41987, IFD: "Exif", Name: "WhiteBalance", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "auto white balance",
+			"1": "manual white balance",
+		},
+		Description: "White balance mode"},
+	{ID: 41988, IFD: "Exif", Name: "DigitalZoomRatio", Types: []uint16{TypeRational}, Count: 1,
+		Description: "Digital zoom ratio, 0 if not used"},
+	{ID: 41989, IFD: "Exif", Name: "FocalLengthIn35mmFilm", Types: []uint16{TypeShort}, Count: 1,
+		Description: "Equivalent focal length for a 35mm film camera, in millimeters"},
+	{ID: 41990, IFD: "Exif", Name: "SceneCaptureType", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "standard",
+			"1": "landscape",
+			"2": "portrait",
+			"3": "night scene",
+		},
+		Description: "Type of scene that was shot"},
+	{ID: 41991, IFD: "Exif", Name: "GainControl", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "none",
+			"1": "low gain up",
+			"2": "high gain up",
+			"3": "low gain down",
+			"4": "high gain down",
+		},
+		Description: "Degree of overall image gain adjustment"},
+	{ID: 41992, IFD: "Exif", Name: "Contrast", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "normal",
+			"1": "soft",
+			"2": "hard",
+		},
+		Description: "Direction of contrast processing"},
+	{ID: 41993, IFD: "Exif", Name: "Saturation", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "normal",
+			"1": "low saturation",
+			"2": "high saturation",
+		},
+		Description: "Direction of saturation processing"},
+	{ID: 41994, IFD: "Exif", Name: "Sharpness", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "normal",
+			"1": "soft",
+			"2": "hard",
+		},
+		Description: "Direction of sharpness processing"},
+	{ID: 41995, IFD: "Exif", Name: "DeviceSettingDescription", Types: []uint16{TypeUndefined},
+		Description: "Picture-taking conditions of a particular camera model"},
+	{ID: 41996, IFD: "Exif", Name: "SubjectDistanceRange", Types: []uint16{TypeShort}, Count: 1,
+		Values: map[string]string{
+			"0": "unknown",
+			"1": "macro",
+			"2": "close view",
+			"3": "distant view",
+		},
+		Description: "Distance to the subject"},
+	// Interoperability IFD
+	{ID: 40965, IFD: "Exif", Name: "InteroperabilityIFDPointer", Types: offsetTypes, Count: 1,
+		Description: "Offset of the Interoperability IFD"},
+	// H. Other Tags
+	{ID: 42016, IFD: "Exif", Name: "ImageUniqueID", Types: []uint16{TypeASCII}, Count: 33,
+		Description: "Unique identifier of the image, as 32 hexadecimal digits"},
+	{ID: 42032, IFD: "Exif", Name: "CameraOwnerName", Types: []uint16{TypeASCII},
+		Description: "Name of the owner of the camera"},
+	{ID: 42033, IFD: "Exif", Name: "BodySerialNumber", Types: []uint16{TypeASCII},
+		Description: "Serial number of the body of the camera"},
+	{ID: 42034, IFD: "Exif", Name: "LensSpecification", Types: []uint16{TypeRational}, Count: 4,
+		Description: "Minimum and maximum focal lengths of the lens, and minimum F numbers at these focal lengths"},
+	{ID: 42035, IFD: "Exif", Name: "LensMake", Types: []uint16{TypeASCII},
+		Description: "Manufacturer of the lens"},
+	{ID: 42036, IFD: "Exif", Name: "LensModel", Types: []uint16{TypeASCII},
+		Description: "Model name or number of the lens"},
+	{ID: 42037, IFD: "Exif", Name: "LensSerialNumber", Types: []uint16{TypeASCII},
+		Description: "Serial number of the lens"},
 }
@@ -0,0 +1,84 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import "fmt"
+
+// Rational is an unsigned fraction, as recorded by the TIFF type RATIONAL.
+// Num and Den are kept as found in the file so that no precision is lost.
+type Rational struct {
+	Num uint32 // numerator
+	Den uint32 // denominator
+}
+
+// Float64 returns the value of the fraction, or 0 if the denominator is 0.
+func (r Rational) Float64() float64 {
+	if r.Den == 0 {
+		return 0
+	}
+	return float64(r.Num) / float64(r.Den)
+}
+
+// String returns the fraction formatted as "Num/Den".
+func (r Rational) String() string {
+	return fmt.Sprintf("%d/%d", r.Num, r.Den)
+}
+
+// Reduce returns the irreducible form of the fraction. A fraction with a denominator of 0 is returned unchanged.
+func (r Rational) Reduce() Rational {
+	if r.Den == 0 {
+		return r
+	}
+	d := gcd(uint64(r.Num), uint64(r.Den))
+	return Rational{Num: r.Num / uint32(d), Den: r.Den / uint32(d)}
+}
+
+// SRational is a signed fraction, as recorded by the TIFF type SRATIONAL.
+// Num and Den are kept as found in the file so that no precision is lost.
+type SRational struct {
+	Num int32 // numerator
+	Den int32 // denominator
+}
+
+// Float64 returns the value of the fraction, or 0 if the denominator is 0.
+func (r SRational) Float64() float64 {
+	if r.Den == 0 {
+		return 0
+	}
+	return float64(r.Num) / float64(r.Den)
+}
+
+// String returns the fraction formatted as "Num/Den".
+func (r SRational) String() string {
+	return fmt.Sprintf("%d/%d", r.Num, r.Den)
+}
+
+// Reduce returns the irreducible form of the fraction, with the sign carried by the numerator.
+// A fraction with a denominator of 0 is returned unchanged.
+func (r SRational) Reduce() SRational {
+	if r.Den == 0 {
+		return r
+	}
+	num, den := int64(r.Num), int64(r.Den)
+	if den < 0 {
+		num, den = -num, -den
+	}
+	d := int64(gcd(abs(num), uint64(den)))
+	return SRational{Num: int32(num / d), Den: int32(den / d)}
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
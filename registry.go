@@ -0,0 +1,245 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TagDef is the definition of a tag, used to validate and decode it.
+//
+// Definitions of the tags from Exif 2.31 are built in, applications can add their own private tags
+// using RegisterTag.
+//
+// Values of the CameraSettings array of Canon MakerNotes are defined like tags of a "CanonCameraSettings" IFD,
+// identified by their index in the array.
+type TagDef struct {
+	ID          uint16            // tag identifier
+	IFD         string            // IFD where the tag is recorded: "IFD0", "Exif", "GPS", "Interop", "Nikon", "Canon" or "CanonCameraSettings"
+	Name        string            // name of the tag, as used in Exif 2.31
+	Types       []uint16          // tiff types allowed for the tag, any type if empty
+	Count       uint64            // number of values expected, any number if 0
+	Values      map[string]string // descriptions of the enumerated values, keyed by the value as formatted by Tag.String
+	Description string            // what the tag records
+
+	format func(Tag) string // custom formatting of the value, for values recorded as several numbers
+}
+
+// IFDs whose tags can be defined.
+// Tags of IFD1, of the following IFDs and of SubIFDs share the definitions of IFD0.
+var tagGroups = []string{"IFD0", "Exif", "GPS", "Interop", "Nikon", "Canon", "CanonCameraSettings"}
+
+var registry = struct {
+	sync.RWMutex
+	defs map[string]map[uint16]TagDef // keyed by IFD, then by tag identifier
+}{defs: make(map[string]map[uint16]TagDef)}
+
+func init() {
+	for _, defs := range [][]TagDef{imageTagDefs, photoTagDefs, gpsTagDefs, interopTagDefs, nikonTagDefs, canonTagDefs} {
+		for _, def := range defs {
+			if err := RegisterTag(def); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// RegisterTag adds the definition of a tag.
+// An error is returned if the IFD is unknown, or if a tag with the same identifier or name is already defined in the IFD.
+func RegisterTag(def TagDef) error {
+	if tagGroup(def.IFD) != def.IFD {
+		return fmt.Errorf("can not define tag %d in IFD %q, IFD must be one of %s", def.ID, def.IFD, strings.Join(tagGroups, ", "))
+	}
+	if def.Name == "" {
+		return fmt.Errorf("tag %d in IFD %s has no name", def.ID, def.IFD)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	defs, ok := registry.defs[def.IFD]
+	if !ok {
+		defs = make(map[uint16]TagDef)
+		registry.defs[def.IFD] = defs
+	}
+	if d, ok := defs[def.ID]; ok {
+		return fmt.Errorf("tag %d already defined in IFD %s as %s", def.ID, def.IFD, d.Name)
+	}
+	for _, d := range defs {
+		if d.Name == def.Name {
+			return fmt.Errorf("tag %s already defined in IFD %s with identifier %d", def.Name, def.IFD, d.ID)
+		}
+	}
+	defs[def.ID] = def
+	return nil
+}
+
+// LookupTag returns the definition of the tag with the given identifier, for the IFD with the given name
+// as used as key in Exif.IFDs.
+func LookupTag(ifd string, id uint16) (TagDef, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	def, ok := registry.defs[tagGroup(ifd)][id]
+	return def, ok
+}
+
+// LookupTagByName returns the definition of the tag with the given name, for the IFD with the given name
+// as used as key in Exif.IFDs.
+func LookupTagByName(ifd string, name string) (TagDef, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	for _, def := range registry.defs[tagGroup(ifd)] {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return TagDef{}, false
+}
+
+// tagGroup returns the IFD whose definitions apply to the tags of the IFD with the given name
+func tagGroup(ifd string) string {
+	switch ifd {
+	case "Exif", "GPS", "Interop", "Nikon", "Canon", "CanonCameraSettings":
+		return ifd
+	case "":
+		return ""
+	}
+	return "IFD0"
+}
+
+// Check returns an error if the type or the count of tag does not match the definition.
+func (def TagDef) Check(tag Tag) error {
+	if len(def.Types) > 0 {
+		allowed := false
+		for _, t := range def.Types {
+			allowed = allowed || t == tag.Type
+		}
+		if !allowed {
+			return fmt.Errorf("unexpected type %s for %s", tag.TypeName(), def.Name)
+		}
+	}
+	if def.Count > 0 && tag.Count != def.Count {
+		return fmt.Errorf("unexpected count %d for %s, expected %d", tag.Count, def.Name, def.Count)
+	}
+	return nil
+}
+
+// Describe returns the value of tag as text, using the description of enumerated values if any.
+// Unknown enumerated values are returned as is.
+//
+// Enumerated values recorded as a single UNDEFINED byte, like FileSource, are identified by their numeric value.
+func (def TagDef) Describe(tag Tag) string {
+	if def.format != nil && def.Check(tag) == nil {
+		return def.format(tag)
+	}
+	value := tag.String()
+	if def.Values != nil && tag.Type == TypeUndefined && tag.Count == 1 {
+		value = strconv.FormatUint(tag.uintValues()[0], 10)
+	}
+	if s, ok := def.Values[value]; ok {
+		return s
+	}
+	return value
+}
+
+// describeValue returns the description of an enumerated value of the tag identified by ifd and id.
+// Unknown values are formatted as "typeName(value)", so that they remain visible.
+func describeValue(ifd string, id uint16, typeName string, v interface{}) string {
+	key := fmt.Sprint(v)
+	if def, ok := LookupTag(ifd, id); ok {
+		if s, ok := def.Values[key]; ok {
+			return s
+		}
+	}
+	if _, ok := v.(string); ok {
+		return fmt.Sprintf("%s(%q)", typeName, key)
+	}
+	return fmt.Sprintf("%s(%s)", typeName, key)
+}
+
+// decodeTags sets the fields of the struct pointed by dst from the tags of ifd having a definition.
+// A field receives the tag with the same name, or the name given by its struct tag `nifuda:"name"`.
+//
+// Tags not matching their definition are skipped, with a warning in lenient mode.
+func (f *tiffFile) decodeTags(ifd *IFD, dst interface{}) {
+	v := reflect.ValueOf(dst).Elem()
+	for _, tag := range ifd.Tags {
+		def, ok := LookupTag(ifd.Name, tag.ID)
+		if !ok {
+			continue
+		}
+		field := fieldByTagName(v, def.Name)
+		if !field.IsValid() { // only available as raw tag
+			continue
+		}
+		if err := def.Check(tag); err != nil {
+			if !f.strict {
+				f.warn(tiffError(ifd.Name, tag.ID, ifd.Offset, err))
+			}
+			continue
+		}
+		setField(field, def, tag)
+	}
+}
+
+// fieldByTagName returns the field of struct v receiving the tag with the given name
+func fieldByTagName(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Tag.Get("nifuda") == name || (sf.Tag.Get("nifuda") == "" && sf.Name == name) {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// setField converts the value of tag to the type of field.
+// Scalar fields receive the first value, slice fields receive all values.
+func setField(field reflect.Value, def TagDef, tag Tag) {
+	switch field.Kind() {
+	case reflect.String:
+		if field.Type() == reflect.TypeOf("") {
+			field.SetString(def.Describe(tag))
+		} else { // enumerated types keep the raw value, described by their String method
+			field.SetString(tag.String())
+		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(tag.uintValues()[0])
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(tag.intValues()[0])
+	case reflect.Struct:
+		switch field.Interface().(type) {
+		case Rational:
+			field.Set(reflect.ValueOf(tag.rationalToRational()[0]))
+		case SRational:
+			field.Set(reflect.ValueOf(tag.srationalToSRational()[0]))
+		case Coordinate:
+			field.Set(reflect.ValueOf(newCoordinate(tag.rationalToRational())))
+		}
+	case reflect.Slice:
+		switch field.Type().Elem().Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			U := tag.uintValues()
+			s := reflect.MakeSlice(field.Type(), len(U), len(U))
+			for i, u := range U {
+				s.Index(i).SetUint(u)
+			}
+			field.Set(s)
+		case reflect.Struct:
+			switch field.Interface().(type) {
+			case []Rational:
+				field.Set(reflect.ValueOf(tag.rationalToRational()))
+			case []SRational:
+				field.Set(reflect.ValueOf(tag.srationalToSRational()))
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2018 VinyMeuh. All rights reserved.
+// Use of the source code is governed by a MIT-style license that can be found in the LICENSE file.
+
+package nifuda
+
+// SubIFDTags contains tags describing the image data of an IFD pointed by a SubIFDs tag (330).
+//
+// SubIFDs are mostly used by RAW files (NEF, DNG, ...) to store the full resolution image and its previews.
+// Their tags share the definitions of IFD0.
+type SubIFDTags struct {
+	Name            string // name of the IFD, as used as key in Exif.IFDs
+	NewSubfileType  uint32
+	ImageWidth      uint32
+	ImageLength     uint32
+	Compression     Compression
+	StripOffsets    []uint64
+	RowsPerStrip    uint32
+	StripByteCounts []uint64
+	TileWidth       uint32
+	TileLength      uint32
+	TileOffsets     []uint64
+	TileByteCounts  []uint64
+}
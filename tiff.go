@@ -11,38 +11,47 @@ import (
 	"io"
 )
 
-// TIFF types as defined in page 15 of TIFF Revision 6.0
+// TIFF types as defined in page 15 of TIFF Revision 6.0, completed by IFD type from TIFF Technical Note 1
+// and by 64-bit types from BigTIFF
 const (
-	ttBYTE      uint16 = 1
-	ttASCII            = 2
-	ttSHORT            = 3
-	ttLONG             = 4
-	ttRATIONAL         = 5
-	ttSBYTE            = 6
-	ttUNDEFINED        = 7
-	ttSSHORT           = 8
-	ttSLONG            = 9
-	ttSRATIONAL        = 10
-	ttFLOAT            = 11
-	ttDOUBLE           = 12
+	TypeByte      uint16 = 1
+	TypeASCII            = 2
+	TypeShort            = 3
+	TypeLong             = 4
+	TypeRational         = 5
+	TypeSByte            = 6
+	TypeUndefined        = 7
+	TypeSShort           = 8
+	TypeSLong            = 9
+	TypeSRational        = 10
+	TypeFloat            = 11
+	TypeDouble           = 12
+	TypeIFD              = 13
+	TypeLong8            = 16
+	TypeSLong8           = 17
+	TypeIFD8             = 18
 )
 
 var tiffTypes = map[uint16]struct {
 	name string
-	size uint32
+	size uint64
 }{
-	ttBYTE:      {name: "BYTE", size: 1},
-	ttASCII:     {name: "ASCII", size: 1},
-	ttSHORT:     {name: "SHORT", size: 2},
-	ttLONG:      {name: "LONG", size: 4},
-	ttRATIONAL:  {name: "RATIONAL", size: 8},
-	ttSBYTE:     {name: "SBYTE", size: 1},
-	ttUNDEFINED: {name: "UNDEFINED", size: 1},
-	ttSSHORT:    {name: "SSHORT", size: 2},
-	ttSLONG:     {name: "SLONG", size: 4},
-	ttSRATIONAL: {name: "SRATIONAL", size: 8},
-	ttFLOAT:     {name: "FLOAT", size: 4},
-	ttDOUBLE:    {name: "DOUBLE", size: 8},
+	TypeByte:      {name: "BYTE", size: 1},
+	TypeASCII:     {name: "ASCII", size: 1},
+	TypeShort:     {name: "SHORT", size: 2},
+	TypeLong:      {name: "LONG", size: 4},
+	TypeRational:  {name: "RATIONAL", size: 8},
+	TypeSByte:     {name: "SBYTE", size: 1},
+	TypeUndefined: {name: "UNDEFINED", size: 1},
+	TypeSShort:    {name: "SSHORT", size: 2},
+	TypeSLong:     {name: "SLONG", size: 4},
+	TypeSRational: {name: "SRATIONAL", size: 8},
+	TypeFloat:     {name: "FLOAT", size: 4},
+	TypeDouble:    {name: "DOUBLE", size: 8},
+	TypeIFD:       {name: "IFD", size: 4},
+	TypeLong8:     {name: "LONG8", size: 8},
+	TypeSLong8:    {name: "SLONG8", size: 8},
+	TypeIFD8:      {name: "IFD8", size: 8},
 }
 
 // TIFF is an image file format built on three kind of structure:
@@ -55,36 +64,87 @@ var tiffTypes = map[uint16]struct {
 //
 // IFH contains pointer to the first IFD (IFD0).
 // A valid TIFF file only require the IFH and IFD0.
+//
+// BigTIFF is a variant using version 43, with 8-byte offsets. This changes the layout of the structures:
+//   - IFH is 16 bytes long
+//   - number of directory entries is recorded using 8 bytes
+//   - field entries are 20 bytes long, with an 8-byte count and an 8-byte value or offset
+//   - offset of the next IFD is recorded using 8 bytes
+
+// Limits protecting against malformed or hostile files
+const (
+	maxIFDs        = 1024      // maximum number of IFDs read in a file
+	maxEntries     = 4096      // maximum number of entries read in an IFD
+	maxValueSize   = 64 << 20  // maximum size in bytes of a tag value
+	maxValuesSize  = 256 << 20 // maximum size in bytes of all the tag values read in a file
+	valuesPerBytes = 4         // values read in a file can not exceed this multiple of its size, as they may overlap
+)
 
 // File represents a parsed TIFF file.
 type tiffFile struct {
-	rs      io.ReadSeeker
+	r       io.ReaderAt      // data is only accessed using ReadAt, without any shared cursor
+	size    uint64           // size in bytes of the file, used to bounds-check offsets
 	bo      binary.ByteOrder // byte order used within the file
-	version uint16           // always "42"
-	offset0 uint32           // offset in bytes for IFD0, from the start of the file
+	version uint16           // "42" for classic TIFF, "43" for BigTIFF
+	offset0 uint64           // offset in bytes for IFD0, from the start of the file
+	visited map[uint64]bool  // offsets of the IFDs already read, used to detect loops
+	values  uint64           // size in bytes of the tag values read so far, recorded out of their entry
+	strict  bool             // if false, problems are recorded as warnings instead of aborting the decoding
+	x       *Exif            // decoded data
 }
 
-// Parses TIFF data from an io.ReadSeeker.
-func tiffRead(rs io.ReadSeeker) (*Exif, error) {
+// Parses TIFF data of size bytes from an io.ReaderAt.
+func tiffRead(r io.ReaderAt, size int64, opts Options) (*Exif, error) {
 	x := &Exif{}
-	f := &tiffFile{rs: rs}
+	f := &tiffFile{r: r, size: uint64(size), visited: make(map[uint64]bool), strict: opts.Strict, x: x}
 	if err := f.readIFH(); err != nil {
 		return nil, err
 	}
 
 	err := f.readIFD0(x)
-	if err != nil { // && len(f.ifd0) == 0 { // failed to read ifd0
+	if err != nil { // IFD0 is mandatory, even in lenient mode
 		return nil, err
 	}
 
 	return x, err
 }
 
+// warn records a problem as a warning in lenient mode.
+// In strict mode, the problem is returned as is to abort the decoding.
+func (f *tiffFile) warn(e *FormatError) error {
+	if f.strict {
+		return e
+	}
+	f.addWarning(e)
+	return nil
+}
+
+// addWarning records a problem as a warning, whatever the mode.
+// It is used for optional data like the thumbnail, whose problems must not abort the decoding.
+func (f *tiffFile) addWarning(e *FormatError) {
+	f.x.Warnings = append(f.x.Warnings, Warning{IFD: e.IFD, Tag: e.Tag, Offset: e.Offset, Reason: e.Err.Error()})
+}
+
+// readAt reads len(b) bytes starting at offset
+func (f *tiffFile) readAt(b []byte, offset uint64) error {
+	if !f.inBounds(offset, uint64(len(b))) {
+		return io.ErrUnexpectedEOF
+	}
+	n, err := f.r.ReadAt(b, int64(offset))
+	if n == len(b) { // io.EOF is allowed when reading the last bytes
+		return nil
+	}
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
 // readIFH reads the TIFF Header
 func (f *tiffFile) readIFH() error {
 	header := make([]byte, 8)
-	if _, err := f.rs.Read(header); err != nil {
-		return fmt.Errorf("failed to read 8 bytes: %w", err)
+	if err := f.readAt(header, 0); err != nil {
+		return tiffError("", 0, 0, fmt.Errorf("failed to read 8 bytes: %w", err))
 	}
 
 	// retrieve byte order indication
@@ -95,105 +155,329 @@ func (f *tiffFile) readIFH() error {
 	case "MM": // Motorola big-endian (0x4D4D)
 		f.bo = binary.BigEndian
 	default:
-		return errors.New("invalid tiff byte order indication")
+		return tiffError("", 0, 0, errors.New("invalid tiff byte order indication"))
 	}
 
 	// validate tiff version
 	binary.Read(bytes.NewReader(header[2:4]), f.bo, &f.version)
-	if f.version != 42 {
-		return errors.New("invalid tiff version")
+	switch f.version {
+	case 42:
+		// read offset for IFD0
+		f.offset0 = f.uint(header[4:8])
+	case 43:
+		// BigTIFF header continues with 8 more bytes
+		header = append(header, make([]byte, 8)...)
+		if err := f.readAt(header[8:], 8); err != nil {
+			return tiffError("", 0, 8, fmt.Errorf("failed to read 8 bytes: %w", err))
+		}
+		if f.uint(header[4:6]) != 8 || f.uint(header[6:8]) != 0 {
+			return tiffError("", 0, 4, fmt.Errorf("invalid bigtiff offsets bytesize: %w", ErrUnsupportedFormat))
+		}
+		f.offset0 = f.uint(header[8:16])
+	default:
+		return tiffError("", 0, 2, fmt.Errorf("invalid tiff version %d: %w", f.version, ErrUnsupportedFormat))
 	}
 
-	// read offset for IFD0
-	binary.Read(bytes.NewReader(header[4:8]), f.bo, &f.offset0)
-	if f.offset0 < 8 { // ifd0 can not be located in the first 8 bytes used by IFH
-		return errors.New("invalid offset for ifd0")
+	if f.offset0 < uint64(len(header)) { // ifd0 can not be located in the bytes used by IFH
+		return tiffError("IFD0", 0, f.offset0, errors.New("invalid offset for ifd0"))
 	}
 
 	return nil
 }
 
+// offsetSize returns the size in bytes of offsets, which is also the maximum size of a value recorded inline in a field entry.
+func (f *tiffFile) offsetSize() int {
+	if f.version == 43 {
+		return 8
+	}
+	return 4
+}
+
+// uint decodes an unsigned integer recorded using 2, 4 or 8 bytes.
+func (f *tiffFile) uint(b []byte) uint64 {
+	switch len(b) {
+	case 2:
+		return uint64(f.bo.Uint16(b))
+	case 4:
+		return uint64(f.bo.Uint32(b))
+	case 8:
+		return f.bo.Uint64(b)
+	}
+	return 0
+}
+
 // readID0 reads the first IFD and decode it as Exif data
 func (f *tiffFile) readIFD0(x *Exif) error {
+	x.IFDs = make(map[string]*IFD)
+
 	// IFD0
-	ifd0, err := f.readIFD(f.offset0)
+	ifd0, err := f.readIFD("IFD0", f.offset0)
 	if err != nil {
 		return err
 	}
-	x.Image = parseIFDTagsAsImageTags(ifd0, f.bo)
+	x.IFDs[ifd0.Name] = ifd0
+	x.Chain = append(x.Chain, ifd0)
+	f.decodeTags(ifd0, &x.Image)
+	if err := f.readSubIFDs(x, ifd0); err != nil {
+		return err
+	}
+
+	// IFD1 and following IFDs, each one pointed by its predecessor
+	for i, next := 1, ifd0.Next; next > 0; i++ {
+		ifdN, err := f.readPointedIFD(x, fmt.Sprintf("IFD%d", i), next)
+		if err != nil {
+			return err
+		}
+		if ifdN == nil { // chain is broken
+			break
+		}
+		x.Chain = append(x.Chain, ifdN)
+		if err := f.readSubIFDs(x, ifdN); err != nil {
+			return err
+		}
+		next = ifdN.Next
+	}
+
+	// Thumbnail
+	if ifd1, ok := x.IFDs["IFD1"]; ok {
+		f.decodeTags(ifd1, &x.ThumbnailImage)
+		x.thumbnail = f.readThumbnail(ifd1)
+	}
 
 	// Exif IFD
 	if x.Image.ExifIFD > 0 {
-		exifIFD, err := f.readIFD(x.Image.ExifIFD)
+		exifIFD, err := f.readPointedIFD(x, "Exif", x.Image.ExifIFD)
 		if err != nil {
 			return err
 		}
-		x.Photo = parseIFDTagsAsPhotoTags(exifIFD, f.bo)
+		if exifIFD != nil {
+			f.decodeTags(exifIFD, &x.Photo)
+			f.readMakerNote(x, exifIFD)
+
+			// Interoperability IFD is pointed from the Exif IFD
+			if tag, ok := exifIFD.Tag(40965); ok {
+				x.Image.InteroperabilityIFD = tag.offsetToUint64()[0]
+			}
+		}
 	}
 
 	// GPS IFD
 	if x.Image.GpsIFD > 0 {
-		gpsIFD, err := f.readIFD(x.Image.GpsIFD)
+		gpsIFD, err := f.readPointedIFD(x, "GPS", x.Image.GpsIFD)
 		if err != nil {
 			return err
 		}
-		x.Gps = parseIFDTagsAsGpsTags(gpsIFD, f.bo)
+		if gpsIFD != nil {
+			f.decodeTags(gpsIFD, &x.Gps)
+		}
+	}
+
+	// Interoperability IFD
+	if x.Image.InteroperabilityIFD > 0 {
+		interopIFD, err := f.readPointedIFD(x, "Interop", x.Image.InteroperabilityIFD)
+		if err != nil {
+			return err
+		}
+		if interopIFD != nil {
+			f.decodeTags(interopIFD, &x.Interop)
+		}
 	}
 
 	return nil
 }
 
+// readPointedIFD reads an IFD pointed by another one and adds it to x.IFDs.
+// In lenient mode, an IFD which can not be read is recorded as a warning and nil is returned.
+func (f *tiffFile) readPointedIFD(x *Exif, name string, offset uint64) (*IFD, error) {
+	ifd, err := f.readIFD(name, offset)
+	if err != nil {
+		return nil, f.warn(err)
+	}
+	x.IFDs[ifd.Name] = ifd
+	return ifd, nil
+}
+
+// readSubIFDs reads recursively the IFDs pointed by the SubIFDs tag of parent.
+// Each SubIFD is named from its parent name, for example "IFD0.SubIFD0" or "IFD0.SubIFD0.SubIFD1".
+func (f *tiffFile) readSubIFDs(x *Exif, parent *IFD) error {
+	tag, ok := parent.Tag(330) // SubIFDs
+	if !ok {
+		return nil
+	}
+
+	for i, offset := range tag.offsetToUint64() {
+		subIFD, err := f.readPointedIFD(x, fmt.Sprintf("%s.SubIFD%d", parent.Name, i), offset)
+		if err != nil {
+			return err
+		}
+		if subIFD == nil {
+			continue
+		}
+		t := SubIFDTags{Name: subIFD.Name}
+		f.decodeTags(subIFD, &t)
+		x.SubIFDs = append(x.SubIFDs, t)
+		if err := f.readSubIFDs(x, subIFD); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readThumbnail reads the JPEG compressed thumbnail pointed by JPEGInterchangeFormat and
+// JPEGInterchangeFormatLength tags. Returns nil if those tags are not present, or if the thumbnail
+// can not be read. The thumbnail being optional, problems are recorded as warnings even in strict mode.
+func (f *tiffFile) readThumbnail(ifd *IFD) []byte {
+	start, ok := ifd.Tag(513) // JPEGInterchangeFormat
+	if !ok {
+		return nil
+	}
+	length, ok := ifd.Tag(514) // JPEGInterchangeFormatLength
+	if !ok {
+		return nil
+	}
+	offset, size := start.offsetToUint64()[0], length.offsetToUint64()[0]
+	if !f.inBounds(offset, size) {
+		f.addWarning(tiffError(ifd.Name, start.ID, offset, fmt.Errorf("thumbnail of %d bytes out of bounds: %w", size, io.ErrUnexpectedEOF)))
+		return nil
+	}
+
+	thumbnail := make([]byte, size)
+	if err := f.readAt(thumbnail, offset); err != nil {
+		f.addWarning(tiffError(ifd.Name, start.ID, offset, fmt.Errorf("failed to read thumbnail of %d bytes: %w", size, err)))
+		return nil
+	}
+	return thumbnail
+}
+
+// valuesLimit returns the maximum size in bytes of all the tag values read in the file.
+// Values of several entries can point to the same data, so the limit depends of the size of the file.
+func (f *tiffFile) valuesLimit() uint64 {
+	if f.size > maxValuesSize/valuesPerBytes {
+		return maxValuesSize
+	}
+	return valuesPerBytes * f.size
+}
+
+// inBounds checks that length bytes starting at offset are within the file
+func (f *tiffFile) inBounds(offset uint64, length uint64) bool {
+	return offset <= f.size && length <= f.size-offset
+}
+
 // readIFD read the IFD starting at offset
-func (f *tiffFile) readIFD(offset uint32) (*ifd, error) {
-	f.rs.Seek(int64(offset), io.SeekStart)
-	ifd := ifd{}
+// In lenient mode, entries which can not be read are skipped with a warning.
+func (f *tiffFile) readIFD(name string, offset uint64) (*IFD, *FormatError) {
+	ifd := IFD{Name: name, Offset: offset}
+
+	// an IFD already read means that the file contains a loop
+	if f.visited[offset] {
+		return &ifd, tiffError(name, 0, offset, errors.New("loop detected, IFD already read"))
+	}
+	f.visited[offset] = true
+	if len(f.visited) > maxIFDs {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("too many IFDs, limit is %d", maxIFDs))
+	}
+
+	// sizes depending of classic TIFF or BigTIFF layout
+	offsetSize := f.offsetSize()
+	countSize := 2
+	entrySize := 12
+	if f.version == 43 {
+		countSize = 8
+		entrySize = 20
+	}
+
+	// IFD can not overlap the header and must fit in the file
+	if offset < uint64(2*offsetSize) || !f.inBounds(offset, uint64(countSize+offsetSize)) {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("IFD out of bounds: %w", io.ErrUnexpectedEOF))
+	}
 
 	// read the number of entries
-	entries := make([]byte, 2)
-	if _, err := f.rs.Read(entries); err != nil {
-		return &ifd, fmt.Errorf("failed to read 2 bytes: %w", err)
+	buf := make([]byte, countSize)
+	if err := f.readAt(buf, offset); err != nil {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("failed to read %d bytes: %w", countSize, err))
+	}
+	n := f.uint(buf)
+	if n > (f.size-offset-uint64(countSize+offsetSize))/uint64(entrySize) {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("%d entries out of bounds: %w", n, io.ErrUnexpectedEOF))
+	}
+	entries := int(n) // number of entries read, only the first ones in lenient mode if there are too many
+	if n > maxEntries {
+		if e := tiffError(name, 0, offset, fmt.Errorf("%d entries, limit is %d", n, maxEntries)); f.warn(e) != nil {
+			return &ifd, e
+		}
+		entries = maxEntries
 	}
-	binary.Read(bytes.NewReader(entries), f.bo, &ifd.entries)
 
 	// read the data
-	data := make([]byte, 12*ifd.entries)
-	if _, err := f.rs.Read(data); err != nil {
-		return &ifd, fmt.Errorf("failed to read %d bytes: %w", 12*ifd.entries, err)
+	data := make([]byte, entrySize*entries)
+	if err := f.readAt(data, offset+uint64(countSize)); err != nil {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("failed to read %d bytes: %w", entrySize*entries, err))
 	}
 
-	// read offset for next IFD
-	next := make([]byte, 4)
-	if _, err := f.rs.Read(next); err != nil {
-		return &ifd, fmt.Errorf("failed to read 4 bytes: %w", err)
+	// read offset for next IFD, recorded after all the entries
+	nextOffset := offset + uint64(countSize) + n*uint64(entrySize)
+	if !f.inBounds(nextOffset, uint64(offsetSize)) {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("offset of next IFD out of bounds: %w", io.ErrUnexpectedEOF))
+	}
+	next := make([]byte, offsetSize)
+	if err := f.readAt(next, nextOffset); err != nil {
+		return &ifd, tiffError(name, 0, offset, fmt.Errorf("failed to read %d bytes: %w", offsetSize, err))
 	}
-	binary.Read(bytes.NewReader(next), f.bo, &ifd.next)
+	ifd.Next = f.uint(next)
 
-	// parse raw tags (after offset because of possible nested Seek)
-	ifd.tags = make([]ifdTag, ifd.entries)
-	tag := ifdTag{}
-	for i := 0; i < int(ifd.entries); i++ {
+	// parse raw tags
+	ifd.Tags = make([]Tag, 0, entries)
+	for i := 0; i < entries; i++ {
+		entry := data[entrySize*i : entrySize*(i+1)]
+		tag := Tag{bo: f.bo}
+		binary.Read(bytes.NewReader(entry[0:2]), f.bo, &tag.ID)
+		binary.Read(bytes.NewReader(entry[2:4]), f.bo, &tag.Type)
+		tag.Count = f.uint(entry[4 : 4+offsetSize])
+		value := entry[4+offsetSize:]
 
-		binary.Read(bytes.NewReader(data[12*i:12*i+2]), f.bo, &tag.id)
-		binary.Read(bytes.NewReader(data[12*i+2:12*i+4]), f.bo, &tag.tiffType)
-		binary.Read(bytes.NewReader(data[12*i+4:12*i+8]), f.bo, &tag.count)
+		tiffType, ok := tiffTypes[tag.Type]
+		if !ok {
+			// readers should skip over fields containing an unexpected field type (TIFF 6.0, page 16)
+			if !f.strict {
+				f.warn(tiffError(name, tag.ID, offset, fmt.Errorf("unknown type %d", tag.Type)))
+			}
+			continue
+		}
+		if tag.Count > maxValueSize/tiffType.size {
+			if e := tiffError(name, tag.ID, offset, fmt.Errorf("count %d too large, limit is %d bytes", tag.Count, maxValueSize)); f.warn(e) != nil {
+				return &ifd, e
+			}
+			continue
+		}
 
-		length := tiffTypes[tag.tiffType].size * tag.count
-		if length <= 4 {
-			tag.data = data[12*i+8 : 12*i+8+int(length)]
+		length := tiffType.size * tag.Count
+		if length <= uint64(offsetSize) {
+			tag.Data = value[:length]
 		} else {
-			var offset uint32
-			binary.Read(bytes.NewReader(data[12*i+8:12*i+12]), f.bo, &offset)
-			if _, err := f.rs.Seek(int64(offset), io.SeekStart); err != nil {
-				//return ifd, fmt.Errorf("failed to seek of %d bytes: %w", offset, err)
-				return &ifd, err
+			offset := f.uint(value)
+			if !f.inBounds(offset, length) {
+				if e := tiffError(name, tag.ID, offset, fmt.Errorf("value of %d bytes out of bounds: %w", length, io.ErrUnexpectedEOF)); f.warn(e) != nil {
+					return &ifd, e
+				}
+				continue
+			}
+			if limit := f.valuesLimit(); length > limit-f.values {
+				if e := tiffError(name, tag.ID, offset, fmt.Errorf("value of %d bytes exceeds the limit of %d bytes for all values", length, limit)); f.warn(e) != nil {
+					return &ifd, e
+				}
+				continue
 			}
-			tag.data = make([]byte, length)
-			if _, err := f.rs.Read(tag.data); err != nil {
-				//return ifd, fmt.Errorf("failed to read field value: %w", err)
-				return &ifd, err
+			f.values += length
+			tag.Data = make([]byte, length)
+			tag.offset = offset
+			if err := f.readAt(tag.Data, offset); err != nil {
+				if e := tiffError(name, tag.ID, offset, fmt.Errorf("failed to read value: %w", err)); f.warn(e) != nil {
+					return &ifd, e
+				}
+				continue
 			}
 		}
-		ifd.tags[i] = tag
+		ifd.Tags = append(ifd.Tags, tag)
 	}
 
 	return &ifd, nil